@@ -1,21 +1,61 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/lakshya1goel/job-assistance/config"
 	"github.com/lakshya1goel/job-assistance/internal/api/controller"
+	"github.com/lakshya1goel/job-assistance/internal/api/middleware"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
 	"github.com/lakshya1goel/job-assistance/internal/api/routes"
+	"github.com/lakshya1goel/job-assistance/internal/api/service"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/notify"
+	"github.com/lakshya1goel/job-assistance/internal/scheduler"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func main() {
 	config.LoadEnv()
+	logger.Init(logger.Options{
+		Format: config.GetLogFormat(),
+		Level:  config.GetLogLevel(),
+		Redact: config.GetLogRedact(),
+	})
 
-	router := gin.Default()
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Options{
+		Exporter:    config.GetTraceExporter(),
+		ServiceName: config.GetServiceName(),
+	})
+	if err != nil {
+		logger.FromContext(context.Background()).Error("failed to initialise tracing", "error", err)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+
+	if path := config.GetSkillTaxonomyPath(); path != "" {
+		taxonomy, err := skills.LoadFile(path)
+		if err != nil {
+			logger.FromContext(context.Background()).Error("failed to load skill taxonomy", "path", path, "error", err)
+			shutdownTracing(context.Background())
+			os.Exit(1)
+		}
+		skills.SetDefault(taxonomy)
+	}
+
+	router := gin.New()
+	router.Use(gin.Recovery(), otelgin.Middleware(config.GetServiceName()), middleware.RequestID())
 	allowedOriginsEnv := os.Getenv("ALLOWED_ORIGINS")
 	var allowedOrigins []string
 
@@ -23,8 +63,8 @@ func main() {
 		allowedOrigins = strings.Split(allowedOriginsEnv, ",")
 	}
 	corsConfig := cors.Config{
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", controller.OwnerTokenHeader},
 		AllowCredentials: true,
 	}
 
@@ -35,10 +75,65 @@ func main() {
 		c.JSON(http.StatusOK, gin.H{"message": "Health Check!"})
 	})
 
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	deliveries := repo.NewDeliveryRepository(100)
+	senders := []notify.Sender{notify.NewWebhookSender(config.GetNotifyAllowPrivate())}
+	if smtpConfig := config.GetSMTPConfig(); smtpConfig.Host != "" {
+		senders = append(senders, notify.NewEmailSender(smtpConfig.Host, smtpConfig.Port, smtpConfig.Username, smtpConfig.Password, smtpConfig.From))
+	}
+	notifier := notify.NewDispatcher(deliveries, config.GetNotifyMaxAttempts(), config.GetNotifyRetryBackoff(), senders...)
+
+	jobService := service.NewJobService(
+		repo.NewRunRepository(config.GetMaxStoredRuns()),
+		repo.NewSavedSearchRepository(),
+		deliveries,
+		repo.NewApplicationRepository(),
+		notifier,
+	)
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	waitScheduler := func() {}
+	if config.GetSchedulerEnabled() {
+		waitScheduler = scheduler.NewScheduler(jobService, config.GetSchedulerInterval(), config.GetSchedulerConcurrency()).Start(schedulerCtx)
+	}
+
 	apiRouter := router.Group("/api")
 	{
-		routes.JobRoutes(apiRouter, controller.NewJobController())
+		jobController := controller.NewJobController(jobService)
+		routes.JobRoutes(apiRouter, jobController)
+		routes.RankRoutes(apiRouter, jobController)
+	}
+
+	server := &http.Server{Addr: ":8084", Handler: router}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	log := logger.FromContext(context.Background())
+	select {
+	case err := <-serverErr:
+		log.Error("server stopped", "error", err)
+		stopScheduler()
+		shutdownTracing(context.Background())
+		os.Exit(1)
+	case <-signalCtx.Done():
 	}
 
-	router.Run(":8084")
+	// Stop taking requests, then let scheduled runs and notification
+	// deliveries, including their retries, finish before exiting.
+	log.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Error("failed to shut down server", "error", err)
+	}
+	stopScheduler()
+	waitScheduler()
+	notifier.Wait()
 }
@@ -2,7 +2,11 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -21,3 +25,228 @@ func GetAPIKey() string {
 	}
 	return key
 }
+
+func GetLogFormat() string {
+	return os.Getenv("LOG_FORMAT")
+}
+
+func GetLogLevel() string {
+	return os.Getenv("LOG_LEVEL")
+}
+
+func GetLogRedact() bool {
+	return os.Getenv("LOG_REDACT") != "false"
+}
+
+func GetTraceExporter() string {
+	return os.Getenv("OTEL_TRACES_EXPORTER")
+}
+
+func GetServiceName() string {
+	name := os.Getenv("OTEL_SERVICE_NAME")
+	if name == "" {
+		return "talentx-backend"
+	}
+	return name
+}
+
+type ModelPrice struct {
+	InputPerMillion  float64
+	OutputPerMillion float64
+}
+
+var defaultModelPrices = map[string]ModelPrice{
+	"gemini-2.0-flash": {InputPerMillion: 0.10, OutputPerMillion: 0.40},
+}
+
+// GEMINI_PRICING overrides the default USD-per-million-token prices, e.g.
+// "gemini-2.0-flash=0.10:0.40,gemini-1.5-pro=1.25:5.00".
+func GetModelPrices() map[string]ModelPrice {
+	prices := make(map[string]ModelPrice, len(defaultModelPrices))
+	for model, price := range defaultModelPrices {
+		prices[model] = price
+	}
+
+	for _, entry := range strings.Split(os.Getenv("GEMINI_PRICING"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, rates, hasModel := strings.Cut(entry, "=")
+		input, output, hasRates := strings.Cut(rates, ":")
+		if !hasModel || !hasRates || strings.TrimSpace(model) == "" {
+			slog.Warn("invalid GEMINI_PRICING entry, expected model=input:output", "entry", entry)
+			continue
+		}
+		inputPrice, inErr := strconv.ParseFloat(strings.TrimSpace(input), 64)
+		outputPrice, outErr := strconv.ParseFloat(strings.TrimSpace(output), 64)
+		if inErr != nil || outErr != nil {
+			slog.Warn("invalid GEMINI_PRICING entry, expected model=input:output", "entry", entry)
+			continue
+		}
+		prices[strings.TrimSpace(model)] = ModelPrice{InputPerMillion: inputPrice, OutputPerMillion: outputPrice}
+	}
+
+	return prices
+}
+
+func getEnvInt(name string, fallback int) int {
+	if value := GetOptionalInt(name); value != nil {
+		return *value
+	}
+	return fallback
+}
+
+// GetOptionalInt returns nil when the variable is unset or invalid so the
+// caller's own default applies.
+func GetOptionalInt(name string) *int {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		slog.Warn("invalid environment variable, using default", "name", name, "value", value)
+		return nil
+	}
+	return &parsed
+}
+
+func GetOptionalFloat(name string) *float64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		slog.Warn("invalid environment variable, using default", "name", name, "value", value)
+		return nil
+	}
+	return &parsed
+}
+
+func GetEmbeddingProvider() string {
+	provider := os.Getenv("EMBEDDING_PROVIDER")
+	switch provider {
+	case "":
+		return "gemini"
+	case "gemini", "local":
+		return provider
+	default:
+		slog.Warn("unknown EMBEDDING_PROVIDER, using gemini", "value", provider)
+		return "gemini"
+	}
+}
+
+func GetMaxStoredRuns() int {
+	return getEnvInt("MAX_STORED_RUNS", 100)
+}
+
+func GetFollowUpDays() int {
+	return getEnvInt("FOLLOW_UP_DAYS", 7)
+}
+
+type JobFetchConfig struct {
+	Timeout      time.Duration
+	MaxBytes     int64
+	UserAgent    string
+	AllowPrivate bool
+}
+
+func GetJobFetchConfig() JobFetchConfig {
+	userAgent := os.Getenv("JOB_FETCH_USER_AGENT")
+	if userAgent == "" {
+		userAgent = "Mozilla/5.0 (compatible; TalentX/1.0)"
+	}
+	return JobFetchConfig{
+		Timeout:      time.Duration(getEnvInt("JOB_FETCH_TIMEOUT_SECONDS", 15)) * time.Second,
+		MaxBytes:     int64(getEnvInt("JOB_FETCH_MAX_BYTES", 2<<20)),
+		UserAgent:    userAgent,
+		AllowPrivate: os.Getenv("JOB_FETCH_ALLOW_PRIVATE") == "true",
+	}
+}
+
+func GetSkillTaxonomyPath() string {
+	return os.Getenv("SKILL_TAXONOMY_PATH")
+}
+
+var defaultFXRates = map[string]float64{
+	"USD": 1,
+	"EUR": 0.92,
+	"GBP": 0.79,
+	"CAD": 1.37,
+	"AUD": 1.52,
+	"INR": 83.5,
+	"SGD": 1.35,
+	"JPY": 150,
+}
+
+// FX_RATES overrides the static units-per-USD table used to compare salaries,
+// e.g. "EUR=0.92,INR=83.5".
+func GetFXRates() map[string]float64 {
+	rates := make(map[string]float64, len(defaultFXRates))
+	for currency, rate := range defaultFXRates {
+		rates[currency] = rate
+	}
+
+	for _, entry := range strings.Split(os.Getenv("FX_RATES"), ",") {
+		currency, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			slog.Warn("invalid FX_RATES entry, expected CURRENCY=units_per_usd", "entry", entry)
+			continue
+		}
+		rates[strings.ToUpper(strings.TrimSpace(currency))] = rate
+	}
+
+	return rates
+}
+
+func GetSchedulerEnabled() bool {
+	return os.Getenv("SCHEDULER_ENABLED") == "true"
+}
+
+func GetSchedulerInterval() time.Duration {
+	return time.Duration(getEnvInt("SCHEDULER_INTERVAL_SECONDS", 60)) * time.Second
+}
+
+func GetSchedulerConcurrency() int {
+	return getEnvInt("SCHEDULER_CONCURRENCY", 2)
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+func GetSMTPConfig() SMTPConfig {
+	smtpConfig := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     getEnvInt("SMTP_PORT", 587),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+	if smtpConfig.From == "" {
+		smtpConfig.From = smtpConfig.Username
+	}
+	return smtpConfig
+}
+
+func GetNotifyAllowPrivate() bool {
+	return os.Getenv("NOTIFY_ALLOW_PRIVATE") == "true"
+}
+
+func GetNotifyMaxAttempts() int {
+	return getEnvInt("NOTIFY_MAX_ATTEMPTS", 3)
+}
+
+func GetNotifyRetryBackoff() time.Duration {
+	return time.Duration(getEnvInt("NOTIFY_RETRY_BACKOFF_SECONDS", 2)) * time.Second
+}
@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+)
+
+// selectAnchorJobs picks evenly spaced jobs to be scored in every batch. Their
+// scores give each independent LLM call a shared reference point, so batches
+// can be shifted onto a common scale before they are merged.
+func selectAnchorJobs(jobs []dtos.Job, count int, batchSize int) ([]dtos.Job, []dtos.Job) {
+	count = min(count, MaxAnchorJobs)
+	if count <= 0 || len(jobs) <= batchSize || len(jobs) <= count {
+		return nil, jobs
+	}
+
+	step := len(jobs) / count
+	anchorIndexes := make(map[int]bool, count)
+	anchors := make([]dtos.Job, 0, count)
+	for i := 0; i < count; i++ {
+		anchorIndexes[i*step] = true
+		anchors = append(anchors, jobs[i*step])
+	}
+
+	remaining := make([]dtos.Job, 0, len(jobs)-count)
+	for i, job := range jobs {
+		if !anchorIndexes[i] {
+			remaining = append(remaining, job)
+		}
+	}
+
+	return anchors, remaining
+}
+
+func calibrateBatchScores(ctx context.Context, results []dtos.BatchResult, anchors []dtos.Job) []dtos.RankedJob {
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].BatchIndex < results[j].BatchIndex
+	})
+
+	references := make(map[int]float64, len(anchors))
+	for i := range anchors {
+		total, count := 0.0, 0
+		for _, result := range results {
+			if score, ok := result.AnchorScores[i]; ok && !result.Fallback {
+				total += score
+				count++
+			}
+		}
+		if count > 0 {
+			references[i] = total / float64(count)
+		}
+	}
+
+	log := logger.FromContext(ctx)
+	var calibrated []dtos.RankedJob
+	for _, result := range results {
+		if result.Fallback {
+			calibrated = append(calibrated, result.Jobs...)
+			continue
+		}
+
+		offset, samples := 0.0, 0
+		for i, score := range result.AnchorScores {
+			if reference, ok := references[i]; ok {
+				offset += reference - score
+				samples++
+			}
+		}
+		if samples > 0 {
+			offset /= float64(samples)
+		}
+		if offset != 0 {
+			log.Debug("calibrating ranking batch", "batch", result.BatchIndex+1, "offset", offset)
+		}
+
+		for i, rankedJob := range result.Jobs {
+			if i < len(result.Evaluated) && result.Evaluated[i] {
+				rankedJob.RawPercentMatch = rankedJob.PercentMatch
+				rankedJob.PercentMatch = clampScore(rankedJob.PercentMatch + offset)
+			}
+			calibrated = append(calibrated, rankedJob)
+		}
+	}
+
+	for i, anchor := range anchors {
+		reference, ok := references[i]
+		if !ok {
+			calibrated = append(calibrated, dtos.RankedJob{
+				Job:             anchor,
+				PercentMatch:    50.0,
+				MatchReason:     "Fallback evaluation - AI did not provide evaluation for this job",
+				SkillsMatched:   []string{},
+				MissingSkills:   []string{},
+				ExperienceMatch: "Unknown",
+			})
+			continue
+		}
+
+		for _, result := range results {
+			if _, evaluated := result.AnchorScores[i]; evaluated && !result.Fallback {
+				rankedJob := result.AnchorJobs[i]
+				rankedJob.RawPercentMatch = rankedJob.PercentMatch
+				rankedJob.PercentMatch = clampScore(reference)
+				calibrated = append(calibrated, rankedJob)
+				break
+			}
+		}
+	}
+
+	return calibrated
+}
+
+func clampScore(score float64) float64 {
+	return max(0, min(score, 100))
+}
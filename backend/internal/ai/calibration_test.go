@@ -0,0 +1,234 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func testJobs(n int) []dtos.Job {
+	jobs := make([]dtos.Job, n)
+	for i := range jobs {
+		jobs[i] = dtos.Job{Title: fmt.Sprintf("Job %d", i), URL: fmt.Sprintf("https://jobs.example/%d", i)}
+	}
+	return jobs
+}
+
+func TestSelectAnchorJobs(t *testing.T) {
+	tests := []struct {
+		name        string
+		jobs        int
+		count       int
+		batchSize   int
+		wantAnchors []int
+	}{
+		{"anchors disabled", 30, 0, 10, nil},
+		{"negative count", 30, -1, 10, nil},
+		{"single batch", 10, 2, 10, nil},
+		{"fewer jobs than anchors", 2, 3, 1, nil},
+		{"evenly spaced", 30, 3, 10, []int{0, 10, 20}},
+		{"count capped", 30, 5, 10, []int{0, 10, 20}},
+		{"one job over batch size", 11, 2, 10, []int{0, 5}},
+		{"two jobs over batch size", 12, 3, 10, []int{0, 4, 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := testJobs(tt.jobs)
+			anchors, rest := selectAnchorJobs(jobs, tt.count, tt.batchSize)
+
+			var got []int
+			for _, anchor := range anchors {
+				for i, job := range jobs {
+					if job.URL == anchor.URL {
+						got = append(got, i)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantAnchors) {
+				t.Fatalf("anchors = %v, want %v", got, tt.wantAnchors)
+			}
+			if len(anchors)+len(rest) != len(jobs) {
+				t.Fatalf("got %d anchors and %d remaining jobs, want %d in total", len(anchors), len(rest), len(jobs))
+			}
+			for _, job := range rest {
+				for _, anchor := range anchors {
+					if job.URL == anchor.URL {
+						t.Errorf("anchor %q also returned as a remaining job", anchor.URL)
+					}
+				}
+			}
+		})
+	}
+}
+
+func rankedJob(url string, score float64) dtos.RankedJob {
+	return dtos.RankedJob{Job: dtos.Job{Title: url, URL: url}, PercentMatch: score}
+}
+
+type calibratedScore struct {
+	Score float64
+	Raw   float64
+}
+
+func TestCalibrateBatchScores(t *testing.T) {
+	anchors := []dtos.Job{{Title: "a0", URL: "a0"}, {Title: "a1", URL: "a1"}}
+
+	tests := []struct {
+		name    string
+		results []dtos.BatchResult
+		anchors []dtos.Job
+		want    map[string]calibratedScore
+	}{
+		{
+			name: "batches shifted onto the mean anchor score",
+			results: []dtos.BatchResult{
+				{
+					BatchIndex:   1,
+					Jobs:         []dtos.RankedJob{rankedJob("b1", 50)},
+					Evaluated:    []bool{true},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 80), rankedJob("a1", 40)},
+					AnchorScores: map[int]float64{0: 80, 1: 40},
+				},
+				{
+					BatchIndex:   0,
+					Jobs:         []dtos.RankedJob{rankedJob("b0", 50)},
+					Evaluated:    []bool{true},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 60), rankedJob("a1", 20)},
+					AnchorScores: map[int]float64{0: 60, 1: 20},
+				},
+			},
+			anchors: anchors,
+			want: map[string]calibratedScore{
+				"b0": {60, 50},
+				"b1": {40, 50},
+				"a0": {70, 60},
+				"a1": {30, 20},
+			},
+		},
+		{
+			name: "anchor missing from one response",
+			results: []dtos.BatchResult{
+				{
+					BatchIndex:   0,
+					Jobs:         []dtos.RankedJob{rankedJob("b0", 50)},
+					Evaluated:    []bool{true},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 60), rankedJob("a1", 50)},
+					AnchorScores: map[int]float64{0: 60},
+				},
+				{
+					BatchIndex:   1,
+					Jobs:         []dtos.RankedJob{rankedJob("b1", 50)},
+					Evaluated:    []bool{true},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 80), rankedJob("a1", 40)},
+					AnchorScores: map[int]float64{0: 80, 1: 40},
+				},
+			},
+			anchors: anchors,
+			want: map[string]calibratedScore{
+				"b0": {60, 50},
+				"b1": {45, 50},
+				"a0": {70, 60},
+				"a1": {40, 40},
+			},
+		},
+		{
+			name: "anchor missing from every response",
+			results: []dtos.BatchResult{
+				{
+					BatchIndex:   0,
+					Jobs:         []dtos.RankedJob{rankedJob("b0", 50)},
+					Evaluated:    []bool{true},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 60), rankedJob("a1", 50)},
+					AnchorScores: map[int]float64{0: 60},
+				},
+			},
+			anchors: anchors,
+			want: map[string]calibratedScore{
+				"b0": {50, 50},
+				"a0": {60, 60},
+				"a1": {50, 0},
+			},
+		},
+		{
+			name: "fallback batch left unchanged",
+			results: []dtos.BatchResult{
+				{
+					BatchIndex:   0,
+					Jobs:         []dtos.RankedJob{rankedJob("b0", 50)},
+					Evaluated:    []bool{true},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 60)},
+					AnchorScores: map[int]float64{0: 60},
+				},
+				{
+					BatchIndex:   1,
+					Jobs:         []dtos.RankedJob{rankedJob("b1", 35)},
+					AnchorScores: map[int]float64{0: 90},
+					Fallback:     true,
+				},
+			},
+			anchors: anchors[:1],
+			want: map[string]calibratedScore{
+				"b0": {50, 50},
+				"b1": {35, 0},
+				"a0": {60, 60},
+			},
+		},
+		{
+			name: "unevaluated jobs and clamping",
+			results: []dtos.BatchResult{
+				{
+					BatchIndex:   0,
+					Jobs:         []dtos.RankedJob{rankedJob("b0", 95), rankedJob("b0-missing", 50)},
+					Evaluated:    []bool{true, false},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 50)},
+					AnchorScores: map[int]float64{0: 50},
+				},
+				{
+					BatchIndex:   1,
+					Jobs:         []dtos.RankedJob{rankedJob("b1", 5)},
+					Evaluated:    []bool{true},
+					AnchorJobs:   []dtos.RankedJob{rankedJob("a0", 70)},
+					AnchorScores: map[int]float64{0: 70},
+				},
+			},
+			anchors: anchors[:1],
+			want: map[string]calibratedScore{
+				"b0":         {100, 95},
+				"b0-missing": {50, 0},
+				"b1":         {0, 5},
+				"a0":         {60, 50},
+			},
+		},
+		{
+			name: "no anchors",
+			results: []dtos.BatchResult{
+				{
+					BatchIndex: 0,
+					Jobs:       []dtos.RankedJob{rankedJob("b0", 42)},
+					Evaluated:  []bool{true},
+				},
+			},
+			want: map[string]calibratedScore{
+				"b0": {42, 42},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calibrated := calibrateBatchScores(context.Background(), tt.results, tt.anchors)
+
+			got := make(map[string]calibratedScore, len(calibrated))
+			for _, job := range calibrated {
+				got[job.Job.URL] = calibratedScore{job.PercentMatch, job.RawPercentMatch}
+			}
+			if len(calibrated) != len(tt.want) || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calibrateBatchScores() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"google.golang.org/genai"
+)
+
+const (
+	CoverLetterToneProfessional   = "professional"
+	CoverLetterToneEnthusiastic   = "enthusiastic"
+	CoverLetterToneConversational = "conversational"
+	CoverLetterToneFormal         = "formal"
+
+	CoverLetterLengthShort  = "short"
+	CoverLetterLengthMedium = "medium"
+	CoverLetterLengthLong   = "long"
+
+	maxPromptDescriptionChars = 6000
+)
+
+var CoverLetterTones = []string{
+	CoverLetterToneProfessional,
+	CoverLetterToneEnthusiastic,
+	CoverLetterToneConversational,
+	CoverLetterToneFormal,
+}
+
+var coverLetterWordRanges = map[string]string{
+	CoverLetterLengthShort:  "150-200",
+	CoverLetterLengthMedium: "250-350",
+	CoverLetterLengthLong:   "400-500",
+}
+
+type CoverLetterClient struct {
+	Client *genai.Client
+}
+
+func NewCoverLetterClient(ctx context.Context, apiKey string) *CoverLetterClient {
+	client, err := newGeminiClient(ctx, apiKey)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create cover letter client", "error", err)
+	}
+
+	return &CoverLetterClient{
+		Client: client,
+	}
+}
+
+func ValidCoverLetterLength(length string) bool {
+	_, ok := coverLetterWordRanges[length]
+	return ok
+}
+
+// GenerateCoverLetter writes a Markdown cover letter for the ranked job using
+// only experience that appears in the candidate profile.
+func (c *CoverLetterClient) GenerateCoverLetter(ctx context.Context, profile string, job dtos.RankedJob, options dtos.CoverLetterOptions) (string, error) {
+	if c.Client == nil {
+		return "", fmt.Errorf("gemini client is not initialised")
+	}
+
+	prompt := c.CoverLetterPrompt(profile, job, options)
+	contents := []*genai.Content{
+		genai.NewContentFromText(prompt, genai.RoleUser),
+	}
+
+	temp := float32(0.6)
+	result, err := generateContent(ctx, c.Client, "cover_letter", contents, &genai.GenerateContentConfig{
+		Temperature: &temp,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate cover letter: %w", err)
+	}
+
+	letter := stripMarkdownFence(responseText(result))
+	if letter == "" {
+		return "", fmt.Errorf("empty cover letter response from AI")
+	}
+	return letter, nil
+}
+
+// jobPromptContext renders the parts of a ranked job that generation prompts
+// need, including the ranking's view of how the candidate fits.
+func jobPromptContext(job dtos.RankedJob) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Title: %s\n", job.Job.Title)
+	if job.Job.Company != "" {
+		fmt.Fprintf(&b, "Company: %s\n", job.Job.Company)
+	}
+	if job.Job.Location != "" {
+		fmt.Fprintf(&b, "Location: %s\n", job.Job.Location)
+	}
+	if len(job.Job.RequiredSkills) > 0 {
+		fmt.Fprintf(&b, "Required skills: %s\n", strings.Join(job.Job.RequiredSkills, ", "))
+	}
+	if job.Job.Highlights != nil {
+		if len(job.Job.Highlights.Qualifications) > 0 {
+			fmt.Fprintf(&b, "Qualifications:\n- %s\n", strings.Join(job.Job.Highlights.Qualifications, "\n- "))
+		}
+		if len(job.Job.Highlights.Responsibilities) > 0 {
+			fmt.Fprintf(&b, "Responsibilities:\n- %s\n", strings.Join(job.Job.Highlights.Responsibilities, "\n- "))
+		}
+	}
+	if description := strings.TrimSpace(truncateText(job.Job.Description, maxPromptDescriptionChars)); description != "" {
+		fmt.Fprintf(&b, "Description:\n%s\n", description)
+	}
+	if job.MatchReason != "" {
+		fmt.Fprintf(&b, "\nWhy the candidate matches: %s\n", job.MatchReason)
+	}
+	if len(job.SkillsMatched) > 0 {
+		fmt.Fprintf(&b, "Matched skills: %s\n", strings.Join(job.SkillsMatched, ", "))
+	}
+	if len(job.MissingSkills) > 0 {
+		fmt.Fprintf(&b, "Skills the candidate lacks: %s\n", strings.Join(job.MissingSkills, ", "))
+	}
+	return b.String()
+}
+
+func responseText(result *genai.GenerateContentResponse) string {
+	if result == nil || len(result.Candidates) == 0 || result.Candidates[0].Content == nil {
+		return ""
+	}
+	var text strings.Builder
+	for _, part := range result.Candidates[0].Content.Parts {
+		text.WriteString(part.Text)
+	}
+	return strings.TrimSpace(text.String())
+}
+
+// stripMarkdownFence removes a ```markdown fence the model sometimes wraps
+// its whole answer in.
+func stripMarkdownFence(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") || !strings.HasSuffix(text, "```") || len(text) < 6 {
+		return text
+	}
+	text = strings.TrimSuffix(text, "```")
+	if i := strings.Index(text, "\n"); i != -1 {
+		text = text[i+1:]
+	} else {
+		text = ""
+	}
+	return strings.TrimSpace(text)
+}
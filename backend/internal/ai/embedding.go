@@ -0,0 +1,175 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genai"
+)
+
+const (
+	embeddingModel        = "text-embedding-004"
+	embeddingBatchLimit   = 100
+	bagOfWordsDimensions  = 1024
+	maxEmbeddingTextChars = 4000
+)
+
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	Name() string
+}
+
+type GeminiEmbedder struct {
+	Client *genai.Client
+}
+
+func NewGeminiEmbedder(client *genai.Client) *GeminiEmbedder {
+	return &GeminiEmbedder{Client: client}
+}
+
+func (g *GeminiEmbedder) Name() string {
+	return "gemini"
+}
+
+func (g *GeminiEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if g.Client == nil {
+		return nil, fmt.Errorf("gemini client is not initialised")
+	}
+
+	ctx, span := tracing.Start(ctx, "gemini.embed_content")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("llm.model", embeddingModel),
+		attribute.Int("embedding.texts", len(texts)),
+	)
+
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += embeddingBatchLimit {
+		end := min(start+embeddingBatchLimit, len(texts))
+
+		contents := make([]*genai.Content, 0, end-start)
+		for _, text := range texts[start:end] {
+			contents = append(contents, genai.NewContentFromText(truncateText(text, maxEmbeddingTextChars), genai.RoleUser))
+		}
+
+		result, err := g.Client.Models.EmbedContent(ctx, embeddingModel, contents, &genai.EmbedContentConfig{
+			TaskType: "SEMANTIC_SIMILARITY",
+		})
+		usage.RecordExternalCall(ctx, "Gemini-Embeddings")
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("failed to embed texts: %w", err)
+		}
+		if len(result.Embeddings) != end-start {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", end-start, len(result.Embeddings))
+		}
+
+		for _, embedding := range result.Embeddings {
+			vectors = append(vectors, embedding.Values)
+		}
+	}
+
+	return vectors, nil
+}
+
+type BagOfWordsEmbedder struct{}
+
+func NewBagOfWordsEmbedder() *BagOfWordsEmbedder {
+	return &BagOfWordsEmbedder{}
+}
+
+func (b *BagOfWordsEmbedder) Name() string {
+	return "bag_of_words"
+}
+
+func (b *BagOfWordsEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vector := make([]float32, bagOfWordsDimensions)
+		for _, token := range tokenize(text) {
+			hasher := fnv.New32a()
+			hasher.Write([]byte(token))
+			vector[hasher.Sum32()%bagOfWordsDimensions]++
+		}
+		for i, count := range vector {
+			if count > 0 {
+				vector[i] = float32(1 + math.Log(float64(count)))
+			}
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, nil
+}
+
+type fallbackEmbedder struct {
+	primary  Embedder
+	fallback Embedder
+}
+
+func NewFallbackEmbedder(primary Embedder, fallback Embedder) Embedder {
+	return &fallbackEmbedder{primary: primary, fallback: fallback}
+}
+
+func (f *fallbackEmbedder) Name() string {
+	return f.primary.Name()
+}
+
+func (f *fallbackEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors, err := f.primary.Embed(ctx, texts)
+	if err == nil {
+		return vectors, nil
+	}
+
+	logger.FromContext(ctx).Warn("embedding failed, using fallback embedder",
+		"embedder", f.primary.Name(),
+		"fallback", f.fallback.Name(),
+		"error", err,
+	)
+	return f.fallback.Embed(ctx, texts)
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+
+	tokens := fields[:0]
+	for _, field := range fields {
+		if len(field) > 1 || field == "c" || field == "r" {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+func truncateText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return strings.ToValidUTF8(text[:limit], "")
+}
@@ -0,0 +1,135 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+type stubEmbedder struct {
+	name    string
+	vectors map[string][]float32
+	err     error
+	calls   int
+}
+
+func (s *stubEmbedder) Name() string {
+	return s.name
+}
+
+func (s *stubEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, s.vectors[text])
+	}
+	return vectors, nil
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float32
+		want float64
+	}{
+		{"identical", []float32{1, 2, 3}, []float32{1, 2, 3}, 1},
+		{"scaled", []float32{1, 2, 3}, []float32{2, 4, 6}, 1},
+		{"orthogonal", []float32{1, 0}, []float32{0, 1}, 0},
+		{"opposite", []float32{1, 0}, []float32{-1, 0}, -1},
+		{"diagonal", []float32{1, 0}, []float32{1, 1}, 1 / math.Sqrt2},
+		{"zero vector", []float32{0, 0}, []float32{1, 1}, 0},
+		{"both zero", []float32{0, 0}, []float32{0, 0}, 0},
+		{"length mismatch", []float32{1, 0}, []float32{1, 0, 0}, 0},
+		{"empty", nil, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cosineSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBagOfWordsEmbedder(t *testing.T) {
+	texts := []string{
+		"Senior Go developer, Kubernetes",
+		"senior GO developer kubernetes",
+		"Go developer with PostgreSQL",
+		"Payroll accountant",
+		"",
+	}
+	vectors, err := NewBagOfWordsEmbedder().Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != len(texts) {
+		t.Fatalf("got %d vectors, want %d", len(vectors), len(texts))
+	}
+	for i, vector := range vectors {
+		if len(vector) != bagOfWordsDimensions {
+			t.Fatalf("vector %d has %d dimensions, want %d", i, len(vector), bagOfWordsDimensions)
+		}
+	}
+
+	if got := cosineSimilarity(vectors[0], vectors[1]); math.Abs(got-1) > 1e-6 {
+		t.Errorf("case and punctuation changed the embedding: similarity %v", got)
+	}
+	related := cosineSimilarity(vectors[0], vectors[2])
+	unrelated := cosineSimilarity(vectors[0], vectors[3])
+	if related <= unrelated {
+		t.Errorf("related similarity %v should exceed unrelated similarity %v", related, unrelated)
+	}
+	if got := cosineSimilarity(vectors[0], vectors[4]); got != 0 {
+		t.Errorf("similarity with empty text = %v, want 0", got)
+	}
+}
+
+func TestFallbackEmbedder(t *testing.T) {
+	ctx := context.Background()
+	vectors := map[string][]float32{"go": {1, 0}}
+
+	t.Run("primary succeeds", func(t *testing.T) {
+		primary := &stubEmbedder{name: "primary", vectors: vectors}
+		fallback := &stubEmbedder{name: "fallback", vectors: map[string][]float32{"go": {0, 1}}}
+		embedder := NewFallbackEmbedder(primary, fallback)
+
+		got, err := embedder.Embed(ctx, []string{"go"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[0][0] != 1 || fallback.calls != 0 {
+			t.Errorf("got %v with %d fallback calls, want the primary vector", got, fallback.calls)
+		}
+		if embedder.Name() != "primary" {
+			t.Errorf("Name() = %q, want %q", embedder.Name(), "primary")
+		}
+	})
+
+	t.Run("primary fails", func(t *testing.T) {
+		primary := &stubEmbedder{name: "primary", err: errors.New("quota exceeded")}
+		fallback := &stubEmbedder{name: "fallback", vectors: vectors}
+
+		got, err := NewFallbackEmbedder(primary, fallback).Embed(ctx, []string{"go"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if primary.calls != 1 || fallback.calls != 1 || got[0][0] != 1 {
+			t.Errorf("got %v with %d primary and %d fallback calls", got, primary.calls, fallback.calls)
+		}
+	})
+
+	t.Run("both fail", func(t *testing.T) {
+		primary := &stubEmbedder{name: "primary", err: errors.New("quota exceeded")}
+		fallback := &stubEmbedder{name: "fallback", err: errors.New("unavailable")}
+
+		if _, err := NewFallbackEmbedder(primary, fallback).Embed(ctx, []string{"go"}); err == nil {
+			t.Error("expected an error when both embedders fail")
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genai"
+)
+
+const geminiModel = "gemini-2.0-flash"
+
+func newGeminiClient(ctx context.Context, apiKey string) (*genai.Client, error) {
+	return genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:     apiKey,
+		HTTPClient: tracing.HTTPClient(),
+	})
+}
+
+func generateContent(ctx context.Context, client *genai.Client, operation string, contents []*genai.Content, config *genai.GenerateContentConfig) (*genai.GenerateContentResponse, error) {
+	ctx, span := tracing.Start(ctx, "gemini.generate_content")
+	span.SetAttributes(
+		attribute.String("llm.model", geminiModel),
+		attribute.String("llm.operation", operation),
+	)
+
+	result, err := client.Models.GenerateContent(ctx, geminiModel, contents, config)
+	if err == nil {
+		recordUsage(ctx, operation, result)
+		if result.UsageMetadata != nil {
+			span.SetAttributes(
+				attribute.Int("llm.prompt_tokens", int(result.UsageMetadata.PromptTokenCount)),
+				attribute.Int("llm.response_tokens", int(result.UsageMetadata.CandidatesTokenCount)),
+			)
+		}
+	}
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func recordUsage(ctx context.Context, operation string, result *genai.GenerateContentResponse) {
+	if result == nil || result.UsageMetadata == nil {
+		return
+	}
+	metadata := result.UsageMetadata
+	metrics.RecordTokens(geminiModel, operation, metadata.PromptTokenCount, metadata.CandidatesTokenCount, metadata.TotalTokenCount)
+	usage.RecordLLM(ctx, operation, geminiModel, int(metadata.PromptTokenCount), int(metadata.CandidatesTokenCount), int(metadata.TotalTokenCount))
+}
@@ -0,0 +1,155 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"google.golang.org/genai"
+)
+
+const (
+	gapTopicBonus    = 0.4
+	maxReviewTopics  = 12
+	defaultGapReason = "Listed by the job but not shown in your profile."
+)
+
+var topicPriorityWeights = map[string]float64{
+	"high":   0.6,
+	"medium": 0.4,
+	"low":    0.2,
+}
+
+type InterviewPrepClient struct {
+	Client *genai.Client
+}
+
+func NewInterviewPrepClient(ctx context.Context, apiKey string) *InterviewPrepClient {
+	client, err := newGeminiClient(ctx, apiKey)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create interview prep client", "error", err)
+	}
+
+	return &InterviewPrepClient{
+		Client: client,
+	}
+}
+
+// GenerateInterviewPrep asks for likely questions and review topics. Topic
+// weights are computed here rather than by the model: the model's priority
+// sets a base weight and topics covering one of the candidate's skill gaps
+// get a fixed bonus.
+func (c *InterviewPrepClient) GenerateInterviewPrep(ctx context.Context, profile string, job dtos.RankedJob, skillGaps []string) (*dtos.InterviewPrep, error) {
+	if c.Client == nil {
+		return nil, fmt.Errorf("gemini client is not initialised")
+	}
+
+	contents := []*genai.Content{
+		genai.NewContentFromText(c.InterviewPrepPrompt(profile, job, skillGaps), genai.RoleUser),
+	}
+
+	temp := float32(0.4)
+	result, err := generateContent(ctx, c.Client, "interview_prep", contents, &genai.GenerateContentConfig{
+		Temperature: &temp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate interview prep: %w", err)
+	}
+
+	return parseInterviewPrep(responseText(result), skillGaps)
+}
+
+func parseInterviewPrep(responseText string, skillGaps []string) (*dtos.InterviewPrep, error) {
+	jsonStart := strings.Index(responseText, "{")
+	jsonEnd := strings.LastIndex(responseText, "}") + 1
+	if jsonStart == -1 || jsonEnd == 0 {
+		return nil, fmt.Errorf("no JSON found in interview prep response")
+	}
+
+	var response struct {
+		TechnicalQuestions  []dtos.InterviewQuestion `json:"technical_questions"`
+		BehavioralQuestions []dtos.InterviewQuestion `json:"behavioral_questions"`
+		TopicsToReview      []struct {
+			Topic    string `json:"topic"`
+			Skill    string `json:"skill"`
+			Priority string `json:"priority"`
+			Reason   string `json:"reason"`
+		} `json:"topics_to_review"`
+		QuestionsToAsk []string `json:"questions_to_ask"`
+	}
+	if err := json.Unmarshal([]byte(responseText[jsonStart:jsonEnd]), &response); err != nil {
+		return nil, fmt.Errorf("error parsing interview prep JSON: %w", err)
+	}
+
+	gaps := make(map[string]bool, len(skillGaps))
+	for _, gap := range skillGaps {
+		gaps[strings.ToLower(gap)] = true
+	}
+
+	covered := make(map[string]bool)
+	topics := []dtos.InterviewTopic{}
+	for _, topic := range response.TopicsToReview {
+		if strings.TrimSpace(topic.Topic) == "" {
+			continue
+		}
+		weight, ok := topicPriorityWeights[strings.ToLower(topic.Priority)]
+		if !ok {
+			weight = topicPriorityWeights["medium"]
+		}
+		skill := strings.ToLower(strings.TrimSpace(topic.Skill))
+		isGap := gaps[skill]
+		if isGap {
+			weight += gapTopicBonus
+			covered[skill] = true
+		}
+		topics = append(topics, dtos.InterviewTopic{
+			Topic:    topic.Topic,
+			Weight:   math.Round(weight*100) / 100,
+			SkillGap: isGap,
+			Reason:   topic.Reason,
+		})
+	}
+
+	for _, gap := range skillGaps {
+		if !covered[strings.ToLower(gap)] {
+			topics = append(topics, dtos.InterviewTopic{
+				Topic:    gap,
+				Weight:   topicPriorityWeights["medium"] + gapTopicBonus,
+				SkillGap: true,
+				Reason:   defaultGapReason,
+			})
+		}
+	}
+
+	sort.SliceStable(topics, func(i, j int) bool {
+		return topics[i].Weight > topics[j].Weight
+	})
+
+	prep := &dtos.InterviewPrep{
+		TechnicalQuestions:  nonEmptyQuestions(response.TechnicalQuestions),
+		BehavioralQuestions: nonEmptyQuestions(response.BehavioralQuestions),
+		TopicsToReview:      topics[:min(maxReviewTopics, len(topics))],
+		QuestionsToAsk:      []string{},
+	}
+	for _, question := range response.QuestionsToAsk {
+		if question = strings.TrimSpace(question); question != "" {
+			prep.QuestionsToAsk = append(prep.QuestionsToAsk, question)
+		}
+	}
+	return prep, nil
+}
+
+func nonEmptyQuestions(questions []dtos.InterviewQuestion) []dtos.InterviewQuestion {
+	result := []dtos.InterviewQuestion{}
+	for _, question := range questions {
+		if strings.TrimSpace(question.Question) != "" {
+			result = append(result, question)
+		}
+	}
+	return result
+}
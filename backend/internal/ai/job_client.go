@@ -5,8 +5,15 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/salary"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/genai"
 )
 
@@ -15,17 +22,15 @@ type JobClient struct {
 }
 
 func NewAIClient(ctx context.Context, apiKey string) *JobClient {
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey: apiKey,
-	})
+	client, err := newGeminiClient(ctx, apiKey)
 	if err != nil {
-		fmt.Println(err)
+		logger.FromContext(ctx).Error("failed to create job client", "error", err)
 	}
 
 	return &JobClient{Client: client}
 }
 
-func (a *JobClient) GetJobsFromResume(ctx context.Context, profile string) ([]dtos.Job, error) {
+func (a *JobClient) GetJobsFromResume(ctx context.Context, profile string, targetJobs int) ([]dtos.Job, error) {
 	prompt := a.JobSearchPrompt(profile)
 
 	parts := []*genai.Part{
@@ -44,9 +49,10 @@ func (a *JobClient) GetJobsFromResume(ctx context.Context, profile string) ([]dt
 
 	tools := a.Tools()
 
-	result, err := a.Client.Models.GenerateContent(
+	result, err := generateContent(
 		ctx,
-		"gemini-2.0-flash",
+		a.Client,
+		"job_search",
 		contents,
 		&genai.GenerateContentConfig{
 			Tools: tools,
@@ -57,25 +63,28 @@ func (a *JobClient) GetJobsFromResume(ctx context.Context, profile string) ([]dt
 		return nil, fmt.Errorf("failed to generate content: %w", err)
 	}
 
+	log := logger.FromContext(ctx)
 	var functionCalls []*genai.FunctionCall
 	for _, candidate := range result.Candidates {
 		for _, part := range candidate.Content.Parts {
 			if part.FunctionCall != nil {
 				functionCalls = append(functionCalls, part.FunctionCall)
-				fmt.Printf("AI wants to call: %s\n", part.FunctionCall.Name)
+				log.Debug("model requested tool call", "tool", part.FunctionCall.Name)
 			}
 		}
 	}
 
 	if len(functionCalls) == 0 {
-		fmt.Println("No function calls found in AI response")
+		log.Warn("no function calls found in AI response")
 		return []dtos.Job{}, nil
 	}
 
-	return a.executeParallelJobSearch(functionCalls), nil
+	jobs, searches := a.executeParallelJobSearch(ctx, functionCalls)
+	return fetchMoreJSearchPages(ctx, searches, jobs, targetJobs), nil
 }
 
-func (a *JobClient) executeParallelJobSearch(functionCalls []*genai.FunctionCall) []dtos.Job {
+func (a *JobClient) executeParallelJobSearch(ctx context.Context, functionCalls []*genai.FunctionCall) ([]dtos.Job, []dtos.JSearchParams) {
+	log := logger.FromContext(ctx)
 	var wg sync.WaitGroup
 	results := make(chan dtos.JobSearchResult, len(functionCalls))
 
@@ -84,9 +93,16 @@ func (a *JobClient) executeParallelJobSearch(functionCalls []*genai.FunctionCall
 		go func(fc *genai.FunctionCall) {
 			defer wg.Done()
 
+			ctx, span := tracing.Start(ctx, "tool."+fc.Name)
+			defer span.End()
+
 			query, ok := fc.Args["query"].(string)
+			span.SetAttributes(
+				attribute.String("tool.name", fc.Name),
+				attribute.String("tool.query", query),
+			)
 			if !ok {
-				fmt.Printf("No query found for function %s\n", fc.Name)
+				log.Warn("no query found for tool call", "tool", fc.Name)
 				results <- dtos.JobSearchResult{
 					Jobs:   []dtos.Job{},
 					Error:  fmt.Errorf("no query found for function %s", fc.Name),
@@ -95,20 +111,33 @@ func (a *JobClient) executeParallelJobSearch(functionCalls []*genai.FunctionCall
 				return
 			}
 
-			fmt.Printf("Searching %s for: %s\n", fc.Name, query)
+			log.Info("searching jobs", "tool", fc.Name, "query", query)
 
 			switch fc.Name {
 			case "search_jsearch_jobs":
-				jobs, err := SearchJobsJSearch(query)
+				params := jsearchParamsFromArgs(query, fc.Args)
+				span.SetAttributes(
+					attribute.String("job.source", "JSearch"),
+					attribute.Int("jsearch.page", params.Page),
+					attribute.Int("jsearch.num_pages", params.NumPages),
+				)
+				jobs, err := SearchJobsJSearchWithParams(ctx, params)
+				span.SetAttributes(attribute.Int("job.count", len(jobs)))
+				if err != nil {
+					span.RecordError(err)
+				}
 				results <- dtos.JobSearchResult{
-					Jobs:   jobs,
-					Error:  err,
-					Source: "JSearch",
+					Jobs:    jobs,
+					Error:   err,
+					Source:  "JSearch",
+					JSearch: &params,
 				}
 
 			case "search_structured_jobs":
-				structuredJobs, err := SearchJobsLinkUpStructured(query)
+				span.SetAttributes(attribute.String("job.source", "LinkUp-Structured"))
+				structuredJobs, err := SearchJobsLinkUpStructured(ctx, query)
 				if err != nil {
+					span.RecordError(err)
 					results <- dtos.JobSearchResult{
 						Jobs:   []dtos.Job{},
 						Error:  err,
@@ -116,6 +145,7 @@ func (a *JobClient) executeParallelJobSearch(functionCalls []*genai.FunctionCall
 					}
 				} else {
 					jobs := convertStructuredToRegularJobs(structuredJobs)
+					span.SetAttributes(attribute.Int("job.count", len(jobs)))
 					results <- dtos.JobSearchResult{
 						Jobs:   jobs,
 						Error:  nil,
@@ -124,11 +154,11 @@ func (a *JobClient) executeParallelJobSearch(functionCalls []*genai.FunctionCall
 				}
 
 			default:
-				fmt.Printf("Unknown function: %s\n", fc.Name)
+				log.Warn("unknown tool call", "tool", fc.Name)
 				results <- dtos.JobSearchResult{
 					Jobs:   []dtos.Job{},
 					Error:  fmt.Errorf("unknown function: %s", fc.Name),
-					Source: fc.Name,
+					Source: "unknown",
 				}
 			}
 		}(functionCall)
@@ -140,16 +170,93 @@ func (a *JobClient) executeParallelJobSearch(functionCalls []*genai.FunctionCall
 	}()
 
 	var allJobs []dtos.Job
+	var searches []dtos.JSearchParams
 	for result := range results {
+		metrics.RecordSourceResult(result.Source, len(result.Jobs), result.Error)
 		if result.Error != nil {
-			fmt.Printf("%s error: %v\n", result.Source, result.Error)
+			log.Error("job search failed", "source", result.Source, "error", result.Error)
 		} else {
-			fmt.Printf("%s found %d jobs\n", result.Source, len(result.Jobs))
+			log.Info("job search completed", "source", result.Source, "jobs", len(result.Jobs))
 			allJobs = append(allJobs, result.Jobs...)
+			if result.JSearch != nil && len(result.Jobs) >= jsearchPageSize*result.JSearch.NumPages {
+				searches = append(searches, *result.JSearch)
+			}
+		}
+	}
+
+	return allJobs, searches
+}
+
+// fetchMoreJSearchPages requests the next page of each JSearch query that
+// returned full pages, one round at a time, until the run has enough unique
+// candidates or the queries run dry.
+func fetchMoreJSearchPages(ctx context.Context, searches []dtos.JSearchParams, jobs []dtos.Job, targetJobs int) []dtos.Job {
+	log := logger.FromContext(ctx)
+
+	for len(searches) > 0 && uniqueJobCount(jobs) < targetJobs {
+		var next []dtos.JSearchParams
+		for _, search := range searches {
+			search.Page += search.NumPages
+			search.NumPages = 1
+			if search.Page <= maxJSearchPage {
+				next = append(next, search)
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+
+		log.Info("fetching more jsearch pages", "queries", len(next), "jobs", uniqueJobCount(jobs), "target", targetJobs)
+
+		var wg sync.WaitGroup
+		results := make([]dtos.JobSearchResult, len(next))
+		for i, search := range next {
+			wg.Add(1)
+			go func(i int, search dtos.JSearchParams) {
+				defer wg.Done()
+
+				ctx, span := tracing.Start(ctx, "tool.search_jsearch_jobs.page")
+				defer span.End()
+				span.SetAttributes(
+					attribute.String("tool.query", search.Query),
+					attribute.Int("jsearch.page", search.Page),
+				)
+
+				pageJobs, err := SearchJobsJSearchWithParams(ctx, search)
+				if err != nil {
+					span.RecordError(err)
+				}
+				results[i] = dtos.JobSearchResult{Jobs: pageJobs, Error: err, Source: "JSearch", JSearch: &search}
+			}(i, search)
+		}
+		wg.Wait()
+
+		searches = searches[:0]
+		for _, result := range results {
+			metrics.RecordSourceResult(result.Source, len(result.Jobs), result.Error)
+			if result.Error != nil {
+				log.Error("job search failed", "source", result.Source, "page", result.JSearch.Page, "error", result.Error)
+				continue
+			}
+			jobs = append(jobs, result.Jobs...)
+			if len(result.Jobs) >= jsearchPageSize {
+				searches = append(searches, *result.JSearch)
+			}
 		}
 	}
 
-	return allJobs
+	return jobs
+}
+
+func uniqueJobCount(jobs []dtos.Job) int {
+	seen := make(map[string]bool, len(jobs)*2)
+	count := 0
+	for _, job := range jobs {
+		if !markSeen(seen, job) {
+			count++
+		}
+	}
+	return count
 }
 
 func convertStructuredToRegularJobs(structuredJobs *dtos.JobAnnouncements) []dtos.Job {
@@ -161,22 +268,39 @@ func convertStructuredToRegularJobs(structuredJobs *dtos.JobAnnouncements) []dto
 			location = *structJob.Location
 		}
 
+		requiredSkills := skills.Default().Normalize(structJob.RequiredSkills)
 		description := fmt.Sprintf("Experience Level: %s\nRequired Skills: %s",
 			structJob.ExperienceLevel,
-			strings.Join(structJob.RequiredSkills, ", "),
+			strings.Join(requiredSkills, ", "),
 		)
 
+		var postedAt *time.Time
+		if structJob.PostedDate != nil {
+			postedAt, _ = parsePostedDate(*structJob.PostedDate)
+		}
+
+		var jobSalary *dtos.Salary
 		if structJob.Salary != nil {
+			amount := float64(*structJob.Salary)
+			jobSalary = salary.Normalize(&dtos.Salary{
+				Min:      &amount,
+				Max:      &amount,
+				Currency: "USD",
+				Period:   salary.PeriodYear,
+			})
 			description += fmt.Sprintf("\nSalary: $%d", *structJob.Salary)
 		}
 
 		jobs = append(jobs, dtos.Job{
-			Title:       structJob.JobTitle,
-			Company:     structJob.Company,
-			Location:    location,
-			Description: description,
-			URL:         structJob.JobPostURL,
-			Source:      "LinkUp-Structured",
+			Title:          structJob.JobTitle,
+			Company:        structJob.Company,
+			Location:       location,
+			Description:    description,
+			RequiredSkills: requiredSkills,
+			URL:            structJob.JobPostURL,
+			Source:         "LinkUp-Structured",
+			PostedAt:       postedAt,
+			Salary:         jobSalary,
 		})
 	}
 
@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"google.golang.org/genai"
+)
+
+const maxPostingTextChars = 20000
+
+type JobPostingClient struct {
+	Client *genai.Client
+}
+
+func NewJobPostingClient(ctx context.Context, apiKey string) *JobPostingClient {
+	client, err := newGeminiClient(ctx, apiKey)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create job posting client", "error", err)
+	}
+
+	return &JobPostingClient{
+		Client: client,
+	}
+}
+
+// ExtractJobPosting pulls the structured posting out of page text or text
+// pasted by the user.
+func (c *JobPostingClient) ExtractJobPosting(ctx context.Context, text string) (*dtos.Job, error) {
+	if c.Client == nil {
+		return nil, fmt.Errorf("gemini client is not initialised")
+	}
+
+	contents := []*genai.Content{
+		genai.NewContentFromText(c.JobPostingPrompt(truncateText(text, maxPostingTextChars)), genai.RoleUser),
+	}
+
+	temp := float32(0.1)
+	result, err := generateContent(ctx, c.Client, "job_posting", contents, &genai.GenerateContentConfig{
+		Temperature: &temp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract job posting: %w", err)
+	}
+
+	return parseJobPosting(responseText(result))
+}
+
+func parseJobPosting(responseText string) (*dtos.Job, error) {
+	jsonStart := strings.Index(responseText, "{")
+	jsonEnd := strings.LastIndex(responseText, "}") + 1
+	if jsonStart == -1 || jsonEnd == 0 {
+		return nil, fmt.Errorf("no JSON found in job posting response")
+	}
+
+	var response struct {
+		Title          string   `json:"title"`
+		Company        string   `json:"company"`
+		Location       string   `json:"location"`
+		Description    string   `json:"description"`
+		RequiredSkills []string `json:"required_skills"`
+		EmploymentType string   `json:"employment_type"`
+	}
+	if err := json.Unmarshal([]byte(responseText[jsonStart:jsonEnd]), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse job posting JSON: %w", err)
+	}
+	if strings.TrimSpace(response.Title) == "" {
+		return nil, fmt.Errorf("no job title found in job posting response")
+	}
+
+	employmentType := strings.ToUpper(strings.NewReplacer("-", "", "_", "", " ", "").Replace(response.EmploymentType))
+	if !contains(jsearchEmploymentTypes, employmentType) {
+		employmentType = ""
+	}
+
+	return &dtos.Job{
+		Title:          strings.TrimSpace(response.Title),
+		Company:        strings.TrimSpace(response.Company),
+		Location:       strings.TrimSpace(response.Location),
+		Description:    strings.TrimSpace(response.Description),
+		RequiredSkills: response.RequiredSkills,
+		EmploymentType: employmentType,
+	}, nil
+}
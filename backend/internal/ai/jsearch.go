@@ -1,40 +1,75 @@
 package ai
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/salary"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
 )
 
-func SearchJobsJSearch(query string) ([]dtos.Job, error) {
-	defaultPreference := dtos.LocationPreference{Types: []string{"remote"}}
-	return SearchJobsJSearchWithLocation(query, defaultPreference)
+const (
+	jsearchPageSize    = 10
+	maxJSearchNumPages = 3
+	maxJSearchPage     = 5
+)
+
+var (
+	jsearchDatePosted      = []string{"all", "today", "3days", "week", "month"}
+	jsearchEmploymentTypes = []string{"FULLTIME", "CONTRACTOR", "PARTTIME", "INTERN"}
+	jsearchJobRequirements = []string{"under_3_years_experience", "more_than_3_years_experience", "no_experience", "no_degree"}
+)
+
+func SearchJobsJSearch(ctx context.Context, query string) ([]dtos.Job, error) {
+	return SearchJobsJSearchWithParams(ctx, dtos.JSearchParams{Query: query})
 }
 
-func SearchJobsJSearchWithLocation(query string, locationPreference dtos.LocationPreference) ([]dtos.Job, error) {
+func SearchJobsJSearchWithParams(ctx context.Context, searchParams dtos.JSearchParams) ([]dtos.Job, error) {
 	key := os.Getenv("RAPIDAPI_KEY")
 	host := os.Getenv("RAPIDAPI_HOST")
 
+	searchParams = normalizeJSearchParams(searchParams)
+
 	baseURL := fmt.Sprintf("https://%s/search", host)
 	params := url.Values{}
-	params.Add("query", query)
-	params.Add("num_pages", "10")
+	params.Add("query", searchParams.Query)
+	params.Add("page", strconv.Itoa(searchParams.Page))
+	params.Add("num_pages", strconv.Itoa(searchParams.NumPages))
+	if searchParams.DatePosted != "" {
+		params.Add("date_posted", searchParams.DatePosted)
+	}
+	if searchParams.EmploymentTypes != "" {
+		params.Add("employment_types", searchParams.EmploymentTypes)
+	}
+	if searchParams.JobRequirements != "" {
+		params.Add("job_requirements", searchParams.JobRequirements)
+	}
+	if searchParams.Radius > 0 {
+		params.Add("radius", strconv.FormatFloat(searchParams.Radius, 'f', -1, 64))
+	}
 
 	fullURL := baseURL + "?" + params.Encode()
 
-	req, _ := http.NewRequest("GET", fullURL, nil)
+	req, _ := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
 	req.Header.Add("X-RapidAPI-Key", key)
 	req.Header.Add("X-RapidAPI-Host", host)
 
-	client := &http.Client{}
+	client := tracing.HTTPClient()
+	usage.RecordExternalCall(ctx, "JSearch")
 	resp, err := client.Do(req)
 	if err != nil {
-		fmt.Println(err)
+		logger.FromContext(ctx).Error("jsearch request failed", "error", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -45,26 +80,126 @@ func SearchJobsJSearchWithLocation(query string, locationPreference dtos.Locatio
 		Data []dtos.JSearchJob `json:"data"`
 	}
 	if err := json.Unmarshal(body, &parsed); err != nil {
-		fmt.Println(err)
+		logger.FromContext(ctx).Error("failed to parse jsearch response", "error", err)
 		return nil, err
 	}
 
 	jobs := []dtos.Job{}
 	for _, job := range parsed.Data {
-		location := "Remote"
-		if !job.IsRemote {
-			location = job.Location
+		jobs = append(jobs, convertJSearchJob(ctx, job))
+	}
+	return jobs, nil
+}
+
+func normalizeJSearchParams(params dtos.JSearchParams) dtos.JSearchParams {
+	params.Page = max(1, min(params.Page, maxJSearchPage))
+	params.NumPages = max(1, min(params.NumPages, maxJSearchNumPages))
+	if !slices.Contains(jsearchDatePosted, params.DatePosted) {
+		params.DatePosted = ""
+	}
+	params.EmploymentTypes = filterCSV(strings.ToUpper(params.EmploymentTypes), jsearchEmploymentTypes)
+	params.JobRequirements = filterCSV(strings.ToLower(params.JobRequirements), jsearchJobRequirements)
+	params.Radius = max(0, params.Radius)
+	return params
+}
+
+func jsearchParamsFromArgs(query string, args map[string]any) dtos.JSearchParams {
+	params := dtos.JSearchParams{Query: query}
+	if page, ok := args["page"].(float64); ok {
+		params.Page = int(page)
+	}
+	if numPages, ok := args["num_pages"].(float64); ok {
+		params.NumPages = int(numPages)
+	}
+	if datePosted, ok := args["date_posted"].(string); ok {
+		params.DatePosted = datePosted
+	}
+	if employmentTypes, ok := args["employment_types"].(string); ok {
+		params.EmploymentTypes = employmentTypes
+	}
+	if jobRequirements, ok := args["job_requirements"].(string); ok {
+		params.JobRequirements = jobRequirements
+	}
+	if radius, ok := args["radius"].(float64); ok {
+		params.Radius = radius
+	}
+	return normalizeJSearchParams(params)
+}
+
+func filterCSV(value string, allowed []string) string {
+	var kept []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if slices.Contains(allowed, item) && !slices.Contains(kept, item) {
+			kept = append(kept, item)
+		}
+	}
+	return strings.Join(kept, ",")
+}
+
+func convertJSearchJob(ctx context.Context, job dtos.JSearchJob) dtos.Job {
+	location := "Remote"
+	if !job.IsRemote {
+		location = firstNonEmpty(job.Location, job.State, job.Country)
+	}
+
+	converted := dtos.Job{
+		Title:          job.Title,
+		Company:        job.Company,
+		Location:       location,
+		State:          job.State,
+		Country:        job.Country,
+		Description:    job.Description,
+		URL:            job.URL,
+		Source:         "JSearch",
+		EmploymentType: job.EmploymentType,
+		EmployerLogo:   job.EmployerLogo,
+	}
+
+	if job.PostedAt != "" {
+		postedAt, ok := parsePostedDate(job.PostedAt)
+		if !ok {
+			logger.FromContext(ctx).Debug("invalid jsearch posting date", "posted_at", job.PostedAt)
 		}
-		jobs = append(jobs, dtos.Job{
-			Title:    job.Title,
-			Company:  job.Company,
-			Location: location,
-			URL:      job.URL,
-			Source:   "JSearch",
+		converted.PostedAt = postedAt
+	}
+
+	if job.MinSalary != nil || job.MaxSalary != nil {
+		converted.Salary = salary.Normalize(&dtos.Salary{
+			Min:      job.MinSalary,
+			Max:      job.MaxSalary,
+			Currency: job.SalaryCurrency,
+			Period:   job.SalaryPeriod,
 		})
 	}
-	if len(jobs) > 10 {
-		return jobs[:10], nil
+
+	if job.Highlights != nil {
+		converted.Highlights = &dtos.JobHighlights{
+			Qualifications:   job.Highlights.Qualifications,
+			Responsibilities: job.Highlights.Responsibilities,
+			Benefits:         job.Highlights.Benefits,
+		}
 	}
-	return jobs, nil
+
+	for _, option := range job.ApplyOptions {
+		if option.ApplyLink == "" {
+			continue
+		}
+		converted.ApplyOptions = append(converted.ApplyOptions, dtos.ApplyOption{
+			Publisher: option.Publisher,
+			URL:       option.ApplyLink,
+			IsDirect:  option.IsDirect,
+		})
+	}
+
+	return converted
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
 }
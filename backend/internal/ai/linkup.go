@@ -2,21 +2,25 @@ package ai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
 )
 
-func SearchJobsLinkUpStructured(query string) (*dtos.JobAnnouncements, error) {
+func SearchJobsLinkUpStructured(ctx context.Context, query string) (*dtos.JobAnnouncements, error) {
 	defaultPreference := dtos.LocationPreference{Types: []string{"remote"}}
-	return SearchJobsLinkUpStructuredWithLocation(query, defaultPreference)
+	return SearchJobsLinkUpStructuredWithLocation(ctx, query, defaultPreference)
 }
 
-func SearchJobsLinkUpStructuredWithLocation(query string, locationPreference dtos.LocationPreference) (*dtos.JobAnnouncements, error) {
+func SearchJobsLinkUpStructuredWithLocation(ctx context.Context, query string, locationPreference dtos.LocationPreference) (*dtos.JobAnnouncements, error) {
 	apiKey := os.Getenv("LINKUP_API_KEY")
 	url := os.Getenv("LINKUP_API_URL")
 
@@ -60,6 +64,10 @@ func SearchJobsLinkUpStructuredWithLocation(query string, locationPreference dto
 							"type":        "integer",
 							"description": "Yearly salary, when available",
 						},
+						"posted_date": map[string]interface{}{
+							"type":        "string",
+							"description": "Date the job was posted in YYYY-MM-DD format, when available",
+						},
 						"job_post_url": map[string]interface{}{
 							"type":        "string",
 							"description": "URL to the job announcement",
@@ -89,7 +97,7 @@ func SearchJobsLinkUpStructuredWithLocation(query string, locationPreference dto
 		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -97,7 +105,8 @@ func SearchJobsLinkUpStructuredWithLocation(query string, locationPreference dto
 	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := tracing.HTTPClient()
+	usage.RecordExternalCall(ctx, "LinkUp")
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
@@ -147,3 +156,12 @@ func convertLinkupJobsToStructured(linkupJobs []dtos.LinkupJob) dtos.JobAnnounce
 
 	return dtos.JobAnnouncements{Jobs: jobs}
 }
+
+func parsePostedDate(value string) (*time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if postedAt, err := time.Parse(layout, value); err == nil {
+			return &postedAt, true
+		}
+	}
+	return nil, false
+}
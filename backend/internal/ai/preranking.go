@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (r *RankingClient) preRankJobs(ctx context.Context, profile string, jobs []dtos.Job, topK int) ([]dtos.Job, map[string]float64) {
+	log := logger.FromContext(ctx)
+	if r.Embedder == nil {
+		return jobs[:min(topK, len(jobs))], nil
+	}
+
+	ctx, span := tracing.Start(ctx, "ranking.prerank")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("embedding.provider", r.Embedder.Name()),
+		attribute.Int("prerank.candidates", len(jobs)),
+		attribute.Int("prerank.top_k", topK),
+	)
+
+	texts := make([]string, 0, len(jobs)+1)
+	texts = append(texts, profile)
+	for _, job := range jobs {
+		texts = append(texts, embeddingText(job))
+	}
+
+	vectors, err := r.Embedder.Embed(ctx, texts)
+	if err != nil || len(vectors) != len(texts) {
+		log.Warn("embedding pre-ranking unavailable, keeping arrival order", "error", err)
+		span.RecordError(err)
+		return jobs[:min(topK, len(jobs))], nil
+	}
+
+	type scoredJob struct {
+		job   dtos.Job
+		score float64
+	}
+
+	scored := make([]scoredJob, len(jobs))
+	scores := make(map[string]float64, len(jobs))
+	for i, job := range jobs {
+		score := math.Round(cosineSimilarity(vectors[0], vectors[i+1])*1000) / 10
+		scored[i] = scoredJob{job: job, score: score}
+		urlKey, _ := jobKeys(job)
+		scores[urlKey] = score
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	kept := make([]dtos.Job, 0, min(topK, len(scored)))
+	for _, candidate := range scored[:min(topK, len(scored))] {
+		kept = append(kept, candidate.job)
+	}
+
+	log.Info("pre-ranked jobs with embeddings",
+		"embedder", r.Embedder.Name(),
+		"candidates", len(jobs),
+		"kept", len(kept),
+	)
+	return kept, scores
+}
+
+func embeddingText(job dtos.Job) string {
+	parts := []string{job.Title}
+	if job.Company != "" {
+		parts = append(parts, job.Company)
+	}
+	if job.Location != "" {
+		parts = append(parts, job.Location)
+	}
+	if job.Description != "" {
+		parts = append(parts, job.Description)
+	}
+	return strings.Join(parts, "\n")
+}
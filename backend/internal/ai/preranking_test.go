@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestPreRankJobs(t *testing.T) {
+	jobs := []dtos.Job{
+		{Title: "unrelated", URL: "https://jobs.example/unrelated"},
+		{Title: "partial", URL: "https://jobs.example/partial"},
+		{Title: "exact", URL: "https://jobs.example/exact"},
+		{Title: "partial twin", URL: "https://jobs.example/partial-twin"},
+	}
+	vectors := map[string][]float32{
+		"profile":      {1, 0},
+		"unrelated":    {0, 1},
+		"partial":      {1, 1},
+		"exact":        {2, 0},
+		"partial twin": {1, 1},
+	}
+
+	tests := []struct {
+		name       string
+		embedder   Embedder
+		topK       int
+		wantTitles []string
+		wantScores map[string]float64
+	}{
+		{
+			name:       "ordered by similarity",
+			embedder:   &stubEmbedder{vectors: vectors},
+			topK:       10,
+			wantTitles: []string{"exact", "partial", "partial twin", "unrelated"},
+			wantScores: map[string]float64{
+				"url:https://jobs.example/unrelated":    0,
+				"url:https://jobs.example/partial":      70.7,
+				"url:https://jobs.example/exact":        100,
+				"url:https://jobs.example/partial-twin": 70.7,
+			},
+		},
+		{
+			name:       "top k cut",
+			embedder:   &stubEmbedder{vectors: vectors},
+			topK:       2,
+			wantTitles: []string{"exact", "partial"},
+			wantScores: map[string]float64{
+				"url:https://jobs.example/unrelated":    0,
+				"url:https://jobs.example/partial":      70.7,
+				"url:https://jobs.example/exact":        100,
+				"url:https://jobs.example/partial-twin": 70.7,
+			},
+		},
+		{
+			name:       "no embedder keeps arrival order",
+			topK:       2,
+			wantTitles: []string{"unrelated", "partial"},
+		},
+		{
+			name:       "embedder error keeps arrival order",
+			embedder:   &stubEmbedder{err: errors.New("unavailable")},
+			topK:       3,
+			wantTitles: []string{"unrelated", "partial", "exact"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &RankingClient{Embedder: tt.embedder}
+			kept, scores := client.preRankJobs(context.Background(), "profile", jobs, tt.topK)
+
+			var titles []string
+			for _, job := range kept {
+				titles = append(titles, job.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.wantTitles) {
+				t.Errorf("kept %q, want %q", titles, tt.wantTitles)
+			}
+			if !reflect.DeepEqual(scores, tt.wantScores) {
+				t.Errorf("scores = %v, want %v", scores, tt.wantScores)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
 	"google.golang.org/genai"
 )
 
@@ -13,11 +14,9 @@ type ProfileClient struct {
 }
 
 func NewProfileClient(ctx context.Context, apiKey string) *ProfileClient {
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey: apiKey,
-	})
+	client, err := newGeminiClient(ctx, apiKey)
 	if err != nil {
-		fmt.Printf("Error creating profile client: %v\n", err)
+		logger.FromContext(ctx).Error("failed to create profile client", "error", err)
 	}
 
 	return &ProfileClient{
@@ -29,12 +28,7 @@ func (p *ProfileClient) ExtractCandidateProfile(ctx context.Context, pdfBytes []
 	prompt := p.CandidateProfilePrompt(locationPreference)
 
 	parts := []*genai.Part{
-		{
-			InlineData: &genai.Blob{
-				MIMEType: "application/pdf",
-				Data:     pdfBytes,
-			},
-		},
+		resumePart(pdfBytes),
 		genai.NewPartFromText(prompt),
 	}
 
@@ -43,9 +37,10 @@ func (p *ProfileClient) ExtractCandidateProfile(ctx context.Context, pdfBytes []
 	}
 
 	temp := float32(0.1)
-	result, err := p.Client.Models.GenerateContent(
+	result, err := generateContent(
 		ctx,
-		"gemini-2.0-flash",
+		p.Client,
+		"profile_extraction",
 		contents,
 		&genai.GenerateContentConfig{
 			Temperature: &temp,
@@ -73,3 +68,12 @@ func (p *ProfileClient) ExtractCandidateProfile(ctx context.Context, pdfBytes []
 
 	return profileText, nil
 }
+
+func resumePart(pdfBytes []byte) *genai.Part {
+	return &genai.Part{
+		InlineData: &genai.Blob{
+			MIMEType: "application/pdf",
+			Data:     pdfBytes,
+		},
+	}
+}
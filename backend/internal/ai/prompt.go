@@ -2,6 +2,7 @@ package ai
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
 )
@@ -191,6 +192,7 @@ STEP 2: Call search_structured_jobs function 1 time with comprehensive profile d
 - Match the seniority level accurately (don't search for senior roles if candidate is fresher)
 - Include relevant technologies and frameworks mentioned in the profile
 - Consider industry experience and domain expertise from the profile
+- search_jsearch_jobs also accepts optional filters (employment_types, job_requirements, date_posted, radius) - only set them when the profile clearly calls for them (e.g. "FULLTIME,INTERN" and "no_experience,under_3_years_experience" for a fresher); leave page and num_pages unset
 
 **YOU MUST EXECUTE ALL FUNCTION CALLS - DO NOT STOP AFTER THE FIRST ONE**
 
@@ -211,6 +213,17 @@ Evaluation Criteria:
 5. Industry and domain experience relevance
 6. Overall career trajectory fit
 
+Score each of the following criteria separately (integer between 0-100) in addition to the overall match score:
+- title_fit: criterion 1 (job title alignment)
+- skills_fit: criterion 2 (required skills match)
+- seniority_fit: criterion 3 (seniority level alignment)
+- location_fit: criterion 4 (location and work arrangement)
+- domain_fit: criteria 5 and 6 (industry, domain and career trajectory)
+
+List the skills the job requires that the candidate's profile does not show under "missing_skills".
+
+Posting age: when a job has "posted_days_ago", listings older than two weeks are increasingly likely to be filled. Lower the overall match_score gradually for those, by up to 10 points for listings six weeks or older, but do not let posting age affect the individual criterion scores. Jobs without "posted_days_ago" are not penalized.
+
 Provide your evaluation in the following JSON format for ALL jobs:
 {
 	"evaluations": [
@@ -218,14 +231,30 @@ Provide your evaluation in the following JSON format for ALL jobs:
 			"job_index": 0,
 			"match_score": <integer between 0-100>,
 			"reasons": "<detailed explanation of the match evaluation>",
+			"scores": {
+				"title_fit": <integer between 0-100>,
+				"skills_fit": <integer between 0-100>,
+				"seniority_fit": <integer between 0-100>,
+				"location_fit": <integer between 0-100>,
+				"domain_fit": <integer between 0-100>
+			},
 			"skills_matched": ["<list of matched skills>"],
+			"missing_skills": ["<list of required skills the candidate lacks>"],
 			"experience_match": "<assessment of experience level fit>"
 		},
 		{
 			"job_index": 1,
 			"match_score": <integer between 0-100>,
 			"reasons": "<detailed explanation of the match evaluation>",
+			"scores": {
+				"title_fit": <integer between 0-100>,
+				"skills_fit": <integer between 0-100>,
+				"seniority_fit": <integer between 0-100>,
+				"location_fit": <integer between 0-100>,
+				"domain_fit": <integer between 0-100>
+			},
 			"skills_matched": ["<list of matched skills>"],
+			"missing_skills": ["<list of required skills the candidate lacks>"],
 			"experience_match": "<assessment of experience level fit>"
 		}
 	]
@@ -267,3 +296,139 @@ func joinLocations(locations []string) string {
 	}
 	return result
 }
+
+func (c *CoverLetterClient) CoverLetterPrompt(profile string, job dtos.RankedJob, options dtos.CoverLetterOptions) string {
+	return fmt.Sprintf(`You are an experienced career coach writing a cover letter for the candidate below, tailored to one specific job.
+
+**CANDIDATE PROFILE:**
+%s
+
+**JOB:**
+%s
+**INSTRUCTIONS:**
+- Tone: %s.
+- Length: %s words, excluding the greeting and sign-off.
+- Ground every claim in the candidate profile. Do NOT invent employers, job titles, projects, metrics, degrees, certifications or years of experience that are not in the profile.
+- Connect the candidate's actual experience to the job's requirements, leading with the strongest matches listed above.
+- Do not claim the skills the candidate lacks. If it helps, you may mention a willingness to learn one or two of them.
+- Address the company by name when it is known. Never use placeholders like [Company] or [Your Name]. Close with "Sincerely," and no name.
+- Do not repeat the job description back or list skills as a bare inventory.
+
+Return only the cover letter in Markdown, with no preamble and no code fences.`,
+		profile,
+		jobPromptContext(job),
+		options.Tone,
+		coverLetterWordRanges[options.Length],
+	)
+}
+
+func (p *ProfileClient) ResumeTailoringPrompt(profile string, hasPDF bool, job dtos.RankedJob, keywords []string) string {
+	resumeSource := "The candidate's resume is attached as a PDF."
+	if !hasPDF {
+		resumeSource = "Only this summary of the resume is available. Quote bullets as they appear in it and skip rewrites you cannot ground in it:\n" + profile
+	}
+
+	missing := "none"
+	if len(keywords) > 0 {
+		missing = strings.Join(keywords, ", ")
+	}
+
+	return fmt.Sprintf(`You are an expert resume editor helping a candidate tailor their resume to one specific job.
+
+**CANDIDATE RESUME:**
+%s
+
+**JOB:**
+%s
+**KEYWORDS MISSING FROM THE RESUME:** %s
+
+**INSTRUCTIONS:**
+1. Bullet rewrites: pick up to %d existing resume bullets that would benefit most from rewording for this job. Quote the original bullet exactly as written and give a rewritten version that uses the job's language, leads with impact and keeps every fact true. Do not add employers, metrics, tools or responsibilities the resume does not support.
+2. Keywords: for each missing keyword, say where it could go (e.g. "Skills", or a specific role) and set "supported_by_resume" to true only if the resume shows related experience that justifies adding it. Otherwise explain in "note" that the candidate should add it only if they have real experience.
+3. Section order: list the resume's sections in the order that best suits this job, most relevant first, and explain why.
+4. Summary: one or two sentences on the most important change to make.
+
+Respond with JSON only, in this format:
+{
+	"summary": "<most important change>",
+	"bullet_rewrites": [
+		{"section": "<section or role>", "original": "<exact bullet>", "suggested": "<rewritten bullet>", "reason": "<why this helps for this job>"}
+	],
+	"keywords_to_add": [
+		{"keyword": "<missing keyword>", "placement": "<where to add it>", "supported_by_resume": <true|false>, "note": "<guidance>"}
+	],
+	"section_order": ["<section>", "<section>"],
+	"section_order_reason": "<why this order>"
+}`,
+		resumeSource,
+		jobPromptContext(job),
+		missing,
+		maxBulletRewrites,
+	)
+}
+
+func (c *InterviewPrepClient) InterviewPrepPrompt(profile string, job dtos.RankedJob, skillGaps []string) string {
+	gaps := "none"
+	if len(skillGaps) > 0 {
+		gaps = strings.Join(skillGaps, ", ")
+	}
+
+	company := job.Job.Company
+	if company == "" {
+		company = "the company"
+	}
+
+	return fmt.Sprintf(`You are a senior interviewer preparing a candidate for an interview at %s.
+
+**CANDIDATE PROFILE:**
+%s
+
+**JOB:**
+%s
+**CANDIDATE'S SKILL GAPS FOR THIS JOB:** %s
+
+**INSTRUCTIONS:**
+1. Technical questions: 6-8 questions this interviewer is likely to ask, based on the job's requirements and responsibilities. Mix in questions probing the skill gaps, since interviewers tend to dig into them. For each, say why it is likely to be asked and give a short hint on how the candidate could answer from their actual experience.
+2. Behavioral questions: 4-5 questions suited to the seniority of the role and to what the job description says about the team and culture, each with a hint that points to a relevant experience from the profile. Do not invent experience.
+3. Topics to review: up to 10 topics, each with a priority of "high", "medium" or "low". Prioritize the skill gaps and the job's core requirements. Set "skill" to the skill gap a topic covers, using the exact spelling from the list above, or leave it empty.
+4. Questions to ask the interviewer: 4-5 thoughtful questions specific to this role and %s, not generic ones.
+
+Respond with JSON only, in this format:
+{
+	"technical_questions": [{"question": "<question>", "why": "<why it is likely>", "answer_hint": "<how to answer>"}],
+	"behavioral_questions": [{"question": "<question>", "why": "<why it is likely>", "answer_hint": "<how to answer>"}],
+	"topics_to_review": [{"topic": "<topic>", "skill": "<skill gap or empty>", "priority": "<high|medium|low>", "reason": "<why review it>"}],
+	"questions_to_ask": ["<question>"]
+}`,
+		company,
+		profile,
+		jobPromptContext(job),
+		gaps,
+		company,
+	)
+}
+
+func (c *JobPostingClient) JobPostingPrompt(text string) string {
+	return fmt.Sprintf(`Extract the job posting from the text below. The text may be a copied job ad or the visible text of a careers page, including navigation, cookie banners and links to other jobs - ignore everything that is not part of the main posting.
+
+**TEXT:**
+%s
+
+**INSTRUCTIONS:**
+1. "title": the job title exactly as posted, without the company name.
+2. "company": the hiring company, or empty if not stated.
+3. "location": city, region and country as stated, or "Remote" for remote roles; empty if not stated.
+4. "description": the full posting text - summary, responsibilities, requirements and benefits - as plain text. Copy it; do not summarize or rewrite.
+5. "required_skills": the technical skills, tools and technologies the posting asks for.
+6. "employment_type": one of FULLTIME, PARTTIME, CONTRACTOR or INTERN, or empty if not stated.
+
+Respond with JSON only, in this format:
+{
+	"title": "<title>",
+	"company": "<company>",
+	"location": "<location>",
+	"description": "<description>",
+	"required_skills": ["<skill>"],
+	"employment_type": "<employment type>"
+}`, text)
+}
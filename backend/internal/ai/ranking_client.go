@@ -4,49 +4,87 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/filter"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/genai"
 )
 
 type RankingClient struct {
-	Client *genai.Client
+	Client   *genai.Client
+	Embedder Embedder
 }
 
 func NewRerankingClient(ctx context.Context, apiKey string) *RankingClient {
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey: apiKey,
-	})
+	client, err := newGeminiClient(ctx, apiKey)
 	if err != nil {
-		fmt.Printf("Error creating reranking client: %v\n", err)
+		logger.FromContext(ctx).Error("failed to create reranking client", "error", err)
 	}
 
-	return &RankingClient{Client: client}
+	return &RankingClient{
+		Client:   client,
+		Embedder: NewFallbackEmbedder(NewGeminiEmbedder(client), NewBagOfWordsEmbedder()),
+	}
 }
 
-func (r *RankingClient) RerankJobs(ctx context.Context, profile string, jobs []dtos.Job) ([]dtos.RankedJob, error) {
+func (r *RankingClient) RerankJobs(ctx context.Context, profile string, jobs []dtos.Job, options dtos.RankingOptions) ([]dtos.RankedJob, error) {
+	options = NormalizeRankingOptions(options)
+
+	candidateLimit := options.MaxJobs
+	if options.PreRankTopK > 0 {
+		candidateLimit = MaxRankingJobs
+	}
+
+	jobs = prepareJobsForRanking(ctx, jobs, candidateLimit)
 	if len(jobs) == 0 {
 		return []dtos.RankedJob{}, nil
 	}
 
-	if len(jobs) > 60 {
-		fmt.Printf("Limiting ranking to first 60 jobs out of %d total jobs\n", len(jobs))
-		jobs = jobs[:60]
+	var embeddingScores map[string]float64
+	if options.PreRankTopK > 0 {
+		jobs, embeddingScores = r.preRankJobs(ctx, profile, jobs, min(options.PreRankTopK, options.MaxJobs))
+	}
+
+	var rankedJobs []dtos.RankedJob
+	var err error
+	if len(jobs) > options.BatchSize {
+		rankedJobs, err = r.RerankJobsParallel(ctx, profile, jobs, options)
+	} else {
+		rankedJobs, err = r.rankBatchJobs(ctx, profile, jobs, options.MinScore, options.Weights)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	if len(jobs) > 10 {
-		return r.RerankJobsParallel(ctx, profile, jobs)
+	for i := range rankedJobs {
+		urlKey, _ := jobKeys(rankedJobs[i].Job)
+		if score, ok := embeddingScores[urlKey]; ok {
+			rankedJobs[i].EmbeddingScore = &score
+		}
 	}
 
-	return r.rankBatchJobs(ctx, profile, jobs)
+	if options.TopN > 0 && len(rankedJobs) > options.TopN {
+		rankedJobs = rankedJobs[:options.TopN]
+	}
+
+	return rankedJobs, nil
 }
 
-func (r *RankingClient) RerankJobsParallel(ctx context.Context, candidateProfile string, jobs []dtos.Job) ([]dtos.RankedJob, error) {
-	const batchSize = 10
-	const maxConcurrency = 3
+func (r *RankingClient) RerankJobsParallel(ctx context.Context, candidateProfile string, jobs []dtos.Job, options dtos.RankingOptions) ([]dtos.RankedJob, error) {
+	batchSize := options.BatchSize
+	maxConcurrency := options.MaxConcurrency
+
+	anchors, jobs := selectAnchorJobs(jobs, options.AnchorJobs, batchSize)
 
 	var batches [][]dtos.Job
 	for i := 0; i < len(jobs); i += batchSize {
@@ -54,6 +92,7 @@ func (r *RankingClient) RerankJobsParallel(ctx context.Context, candidateProfile
 		batches = append(batches, jobs[i:end])
 	}
 
+	log := logger.FromContext(ctx)
 	monitorConcurrency := make(chan struct{}, maxConcurrency)
 	results := make(chan dtos.BatchResult, len(batches))
 	var wg sync.WaitGroup
@@ -66,19 +105,46 @@ func (r *RankingClient) RerankJobsParallel(ctx context.Context, candidateProfile
 			monitorConcurrency <- struct{}{}
 			defer func() { <-monitorConcurrency }()
 
-			fmt.Printf("Processing batch %d with %d jobs\n", idx+1, len(jobBatch))
+			ctx, span := tracing.Start(ctx, "ranking.batch")
+			defer span.End()
+			span.SetAttributes(
+				attribute.Int("ranking.batch_index", idx),
+				attribute.Int("ranking.batch_size", len(jobBatch)),
+				attribute.Int("ranking.anchor_jobs", len(anchors)),
+			)
+
+			log.Debug("processing ranking batch", "batch", idx+1, "jobs", len(jobBatch), "anchors", len(anchors))
 
-			rankedBatch, err := r.rankBatchJobs(ctx, candidateProfile, jobBatch)
+			batchWithAnchors := append(append(make([]dtos.Job, 0, len(jobBatch)+len(anchors)), jobBatch...), anchors...)
+			evaluatedBatch, evaluated, err := r.evaluateBatchJobs(ctx, candidateProfile, batchWithAnchors, options.Weights)
 			if err != nil {
-				fmt.Printf("Error ranking batch %d: %v, using fallback\n", idx+1, err)
-				rankedBatch = r.fallbackRanking(jobBatch)
+				log.Warn("ranking batch failed, using fallback", "batch", idx+1, "error", err)
+				span.RecordError(err)
+				span.SetAttributes(attribute.Bool("ranking.fallback", true))
+				metrics.RecordRankingBatch(metrics.BatchFallback)
+				results <- dtos.BatchResult{
+					Jobs:       r.fallbackRanking(ctx, jobBatch, options.MinScore),
+					BatchIndex: idx,
+					Fallback:   true,
+				}
+				return
 			}
 
-			fmt.Printf("Completed batch %d with %d ranked jobs\n", idx+1, len(rankedBatch))
+			anchorScores := make(map[int]float64, len(anchors))
+			for i := range anchors {
+				if evaluated[len(jobBatch)+i] {
+					anchorScores[i] = evaluatedBatch[len(jobBatch)+i].PercentMatch
+				}
+			}
+
+			log.Debug("completed ranking batch", "batch", idx+1, "ranked_jobs", len(jobBatch))
 
 			results <- dtos.BatchResult{
-				Jobs:       rankedBatch,
-				BatchIndex: idx,
+				Jobs:         evaluatedBatch[:len(jobBatch)],
+				Evaluated:    evaluated[:len(jobBatch)],
+				AnchorJobs:   evaluatedBatch[len(jobBatch):],
+				AnchorScores: anchorScores,
+				BatchIndex:   idx,
 			}
 		}(batchIndex, batch)
 	}
@@ -88,32 +154,52 @@ func (r *RankingClient) RerankJobsParallel(ctx context.Context, candidateProfile
 		close(results)
 	}()
 
-	var allRanked []dtos.RankedJob
-	batchCount := 0
+	var batchResults []dtos.BatchResult
 	for result := range results {
-		allRanked = append(allRanked, result.Jobs...)
-		batchCount++
-		fmt.Printf("Collected results from batch %d (%d/%d batches complete)\n", result.BatchIndex+1, batchCount, len(batches))
+		batchResults = append(batchResults, result)
+		log.Debug("collected ranking batch", "batch", result.BatchIndex+1, "completed", len(batchResults), "total", len(batches))
 	}
 
-	sort.Slice(allRanked, func(i, j int) bool {
-		return allRanked[i].PercentMatch > allRanked[j].PercentMatch
-	})
+	allRanked := calibrateBatchScores(ctx, batchResults, anchors)
+	allRanked = filterAndSortRankedJobs(allRanked, options.MinScore)
 
-	fmt.Printf("Successfully ranked and sorted %d jobs from %d batches\n", len(allRanked), len(batches))
+	log.Info("ranked jobs", "jobs", len(allRanked), "batches", len(batches), "anchors", len(anchors))
 	return allRanked, nil
 }
 
-func (r *RankingClient) rankBatchJobs(ctx context.Context, candidateProfile string, jobs []dtos.Job) ([]dtos.RankedJob, error) {
+// RankJob scores a single job against the profile. Unlike RerankJobs it
+// never drops the job for scoring below the minimum.
+func (r *RankingClient) RankJob(ctx context.Context, profile string, job dtos.Job, weights dtos.ScoreWeights) (*dtos.RankedJob, error) {
+	rankedJobs, err := r.rankBatchJobs(ctx, profile, []dtos.Job{job}, 0, weights)
+	if err != nil {
+		return nil, err
+	}
+	if len(rankedJobs) == 0 {
+		return nil, fmt.Errorf("no ranking returned for job")
+	}
+	return &rankedJobs[0], nil
+}
+
+func (r *RankingClient) rankBatchJobs(ctx context.Context, candidateProfile string, jobs []dtos.Job, minScore float64, weights dtos.ScoreWeights) ([]dtos.RankedJob, error) {
 	if len(jobs) == 0 {
 		return []dtos.RankedJob{}, nil
 	}
 
+	rankedJobs, _, err := r.evaluateBatchJobs(ctx, candidateProfile, jobs, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterAndSortRankedJobs(rankedJobs, minScore), nil
+}
+
+func (r *RankingClient) evaluateBatchJobs(ctx context.Context, candidateProfile string, jobs []dtos.Job, weights dtos.ScoreWeights) ([]dtos.RankedJob, []bool, error) {
 	var jobsJSON []string
+	now := time.Now()
 	for i, job := range jobs {
-		jobJSON, err := json.MarshalIndent(job, "", "  ")
+		jobJSON, err := json.MarshalIndent(rankingView(job, now), "", "  ")
 		if err != nil {
-			fmt.Printf("Error marshaling job %d: %v\n", i, err)
+			logger.FromContext(ctx).Warn("failed to marshal job", "index", i, "error", err)
 			continue
 		}
 		jobsJSON = append(jobsJSON, string(jobJSON))
@@ -146,9 +232,10 @@ func (r *RankingClient) rankBatchJobs(ctx context.Context, candidateProfile stri
 	}
 
 	temp := float32(0.1)
-	result, err := r.Client.Models.GenerateContent(
+	result, err := generateContent(
 		ctx,
-		"gemini-2.0-flash",
+		r.Client,
+		"ranking",
 		contents,
 		&genai.GenerateContentConfig{
 			Temperature: &temp,
@@ -156,11 +243,11 @@ func (r *RankingClient) rankBatchJobs(ctx context.Context, candidateProfile stri
 	)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to evaluate job batch: %w", err)
+		return nil, nil, fmt.Errorf("failed to evaluate job batch: %w", err)
 	}
 
 	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
-		return nil, fmt.Errorf("no evaluation response from AI")
+		return nil, nil, fmt.Errorf("no evaluation response from AI")
 	}
 
 	responseText := ""
@@ -170,14 +257,18 @@ func (r *RankingClient) rankBatchJobs(ctx context.Context, candidateProfile stri
 		}
 	}
 
-	rankedJobs, err := r.parseBatchJobEvaluation(responseText, jobs)
+	rankedJobs, evaluated, err := r.parseBatchJobEvaluation(ctx, responseText, jobs, weights)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse batch evaluation response: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse batch evaluation response: %w", err)
 	}
 
+	return rankedJobs, evaluated, nil
+}
+
+func filterAndSortRankedJobs(rankedJobs []dtos.RankedJob, minScore float64) []dtos.RankedJob {
 	var filteredJobs []dtos.RankedJob
 	for _, rankedJob := range rankedJobs {
-		if rankedJob.PercentMatch >= 30.0 {
+		if rankedJob.PercentMatch >= minScore {
 			filteredJobs = append(filteredJobs, rankedJob)
 		}
 	}
@@ -186,91 +277,118 @@ func (r *RankingClient) rankBatchJobs(ctx context.Context, candidateProfile stri
 		return filteredJobs[i].PercentMatch > filteredJobs[j].PercentMatch
 	})
 
-	return filteredJobs, nil
+	return filteredJobs
 }
 
-func (r *RankingClient) parseBatchJobEvaluation(responseText string, jobs []dtos.Job) ([]dtos.RankedJob, error) {
+func (r *RankingClient) parseBatchJobEvaluation(ctx context.Context, responseText string, jobs []dtos.Job, weights dtos.ScoreWeights) ([]dtos.RankedJob, []bool, error) {
 	jsonStart := strings.Index(responseText, "{")
 	jsonEnd := strings.LastIndex(responseText, "}") + 1
 
 	if jsonStart == -1 || jsonEnd == 0 {
-		return nil, fmt.Errorf("no JSON found in response")
+		return nil, nil, fmt.Errorf("no JSON found in response")
 	}
 
 	jsonStr := responseText[jsonStart:jsonEnd]
 
 	var batchEvaluation struct {
 		Evaluations []struct {
-			JobIndex        int      `json:"job_index"`
-			MatchScore      int      `json:"match_score"`
-			Reasons         string   `json:"reasons"`
+			JobIndex   int    `json:"job_index"`
+			MatchScore int    `json:"match_score"`
+			Reasons    string `json:"reasons"`
+			Scores     *struct {
+				TitleFit     *float64 `json:"title_fit"`
+				SkillsFit    *float64 `json:"skills_fit"`
+				SeniorityFit *float64 `json:"seniority_fit"`
+				LocationFit  *float64 `json:"location_fit"`
+				DomainFit    *float64 `json:"domain_fit"`
+			} `json:"scores"`
 			SkillsMatched   []string `json:"skills_matched"`
+			MissingSkills   []string `json:"missing_skills"`
 			ExperienceMatch string   `json:"experience_match"`
 		} `json:"evaluations"`
 	}
 
 	err := json.Unmarshal([]byte(jsonStr), &batchEvaluation)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing batch evaluation JSON: %w", err)
+		return nil, nil, fmt.Errorf("error parsing batch evaluation JSON: %w", err)
 	}
 
-	var rankedJobs []dtos.RankedJob
+	rankedJobs := make([]dtos.RankedJob, len(jobs))
+	evaluated := make([]bool, len(jobs))
+	evaluatedCount := 0
 	for _, evaluation := range batchEvaluation.Evaluations {
 		if evaluation.JobIndex < 0 || evaluation.JobIndex >= len(jobs) {
-			fmt.Printf("Invalid job index %d, skipping\n", evaluation.JobIndex)
+			logger.FromContext(ctx).Warn("invalid job index in evaluation, skipping", "job_index", evaluation.JobIndex)
 			continue
 		}
 
+		if !evaluated[evaluation.JobIndex] {
+			evaluatedCount++
+		}
+		evaluated[evaluation.JobIndex] = true
 		rankedJob := dtos.RankedJob{
 			Job:             jobs[evaluation.JobIndex],
 			PercentMatch:    float64(evaluation.MatchScore),
 			MatchReason:     evaluation.Reasons,
-			SkillsMatched:   evaluation.SkillsMatched,
+			SkillsMatched:   skills.Default().Normalize(evaluation.SkillsMatched),
+			MissingSkills:   missingSkills(evaluation.SkillsMatched, evaluation.MissingSkills),
 			ExperienceMatch: evaluation.ExperienceMatch,
 		}
-		rankedJobs = append(rankedJobs, rankedJob)
-	}
-
-	if len(rankedJobs) < len(jobs) {
-		fmt.Printf("Warning: Only got %d evaluations for %d jobs, using fallback for missing ones\n", len(rankedJobs), len(jobs))
 
-		evaluatedJobs := make(map[int]bool)
-		for _, evaluation := range batchEvaluation.Evaluations {
-			if evaluation.JobIndex >= 0 && evaluation.JobIndex < len(jobs) {
-				evaluatedJobs[evaluation.JobIndex] = true
+		scores := evaluation.Scores
+		if scores != nil && scores.TitleFit != nil && scores.SkillsFit != nil && scores.SeniorityFit != nil &&
+			scores.LocationFit != nil && scores.DomainFit != nil {
+			breakdown := dtos.MatchBreakdown{
+				TitleFit:     clampScore(*scores.TitleFit),
+				SkillsFit:    clampScore(*scores.SkillsFit),
+				SeniorityFit: clampScore(*scores.SeniorityFit),
+				LocationFit:  clampScore(*scores.LocationFit),
+				DomainFit:    clampScore(*scores.DomainFit),
 			}
+			rankedJob.Breakdown = &breakdown
+			score := weightedScore(breakdown, weights) - recencyPenalty(rankedJob.Job, time.Now())
+			rankedJob.PercentMatch = math.Round(clampScore(score)*10) / 10
 		}
+		rankedJobs[evaluation.JobIndex] = rankedJob
+	}
+
+	if evaluatedCount >= len(jobs) {
+		metrics.RecordRankingBatch(metrics.BatchParsed)
+	} else {
+		metrics.RecordRankingBatch(metrics.BatchPartial)
+		logger.FromContext(ctx).Warn("missing evaluations, using fallback for missing jobs", "evaluations", evaluatedCount, "jobs", len(jobs))
 
 		for i := 0; i < len(jobs); i++ {
-			if !evaluatedJobs[i] {
-				rankedJob := dtos.RankedJob{
+			if !evaluated[i] {
+				rankedJobs[i] = dtos.RankedJob{
 					Job:             jobs[i],
 					PercentMatch:    50.0,
 					MatchReason:     "Fallback evaluation - AI did not provide evaluation for this job",
 					SkillsMatched:   []string{},
+					MissingSkills:   []string{},
 					ExperienceMatch: "Unknown",
 				}
-				rankedJobs = append(rankedJobs, rankedJob)
 			}
 		}
 	}
 
-	return rankedJobs, nil
+	return rankedJobs, evaluated, nil
 }
 
-func (r *RankingClient) fallbackRanking(jobs []dtos.Job) []dtos.RankedJob {
-	fmt.Println("Using fallback ranking - returning jobs in original order")
+func (r *RankingClient) fallbackRanking(ctx context.Context, jobs []dtos.Job, minScore float64) []dtos.RankedJob {
+	logger.FromContext(ctx).Warn("using fallback ranking, returning jobs in original order", "jobs", len(jobs))
 	var rankedJobs []dtos.RankedJob
 
 	for i, job := range jobs {
 		percentage := 80.0 - float64(i)*5.0
 
-		if percentage >= 30.0 {
+		if percentage >= minScore {
 			rankedJobs = append(rankedJobs, dtos.RankedJob{
 				Job:             job,
 				PercentMatch:    percentage,
 				MatchReason:     "Fallback ranking - AI parsing failed",
 				SkillsMatched:   []string{},
+				MissingSkills:   []string{},
 				ExperienceMatch: "Unknown",
 			})
 		}
@@ -278,3 +396,48 @@ func (r *RankingClient) fallbackRanking(jobs []dtos.Job) []dtos.RankedJob {
 
 	return rankedJobs
 }
+
+func missingSkills(matched, missing []string) []string {
+	taxonomy := skills.Default()
+	matchedSet := make(map[string]bool, len(matched))
+	for _, skill := range taxonomy.Normalize(matched) {
+		matchedSet[skill] = true
+	}
+
+	result := []string{}
+	for _, skill := range taxonomy.Normalize(missing) {
+		if !matchedSet[skill] {
+			result = append(result, skill)
+		}
+	}
+	return result
+}
+
+type rankingJob struct {
+	dtos.Job
+	PostedDaysAgo *int `json:"posted_days_ago,omitempty"`
+}
+
+// rankingView drops display-only fields and caps the description so a batch
+// of full JSearch postings stays within a reasonable prompt size.
+func rankingView(job dtos.Job, now time.Time) rankingJob {
+	job.Description = truncateText(job.Description, maxRankingDescriptionChars)
+	job.EmployerLogo = ""
+	job.ApplyOptions = nil
+
+	view := rankingJob{Job: job}
+	if days, ok := filter.AgeInDays(job, now); ok {
+		view.PostedDaysAgo = &days
+	}
+	return view
+}
+
+// recencyPenalty mirrors the prompt's guidance on stale postings for scores
+// computed from the criterion breakdown, which deliberately ignores age.
+func recencyPenalty(job dtos.Job, now time.Time) float64 {
+	days, ok := filter.AgeInDays(job, now)
+	if !ok || days <= freshPostingDays {
+		return 0
+	}
+	return math.Min(float64(days-freshPostingDays)/float64(stalePostingDays-freshPostingDays), 1) * maxRecencyPenalty
+}
@@ -0,0 +1,144 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestNormalizeWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights dtos.ScoreWeights
+		want    dtos.ScoreWeights
+	}{
+		{"all zero", dtos.ScoreWeights{}, DefaultScoreWeights},
+		{"all negative", dtos.ScoreWeights{Title: -1, Skills: -2}, DefaultScoreWeights},
+		{"defaults unchanged", DefaultScoreWeights, DefaultScoreWeights},
+		{"scaled to one", dtos.ScoreWeights{Title: 2, Skills: 2}, dtos.ScoreWeights{Title: 0.5, Skills: 0.5}},
+		{"negative dropped", dtos.ScoreWeights{Title: 3, Skills: 1, Domain: -4}, dtos.ScoreWeights{Title: 0.75, Skills: 0.25}},
+		{
+			"equal weights",
+			dtos.ScoreWeights{Title: 1, Skills: 1, Seniority: 1, Location: 1, Domain: 1},
+			dtos.ScoreWeights{Title: 0.2, Skills: 0.2, Seniority: 0.2, Location: 0.2, Domain: 0.2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeWeights(tt.weights)
+			if !weightsEqual(got, tt.want) {
+				t.Errorf("normalizeWeights(%+v) = %+v, want %+v", tt.weights, got, tt.want)
+			}
+		})
+	}
+}
+
+func weightsEqual(a, b dtos.ScoreWeights) bool {
+	const epsilon = 1e-9
+	return math.Abs(a.Title-b.Title) < epsilon &&
+		math.Abs(a.Skills-b.Skills) < epsilon &&
+		math.Abs(a.Seniority-b.Seniority) < epsilon &&
+		math.Abs(a.Location-b.Location) < epsilon &&
+		math.Abs(a.Domain-b.Domain) < epsilon
+}
+
+func TestWeightedScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		breakdown dtos.MatchBreakdown
+		weights   dtos.ScoreWeights
+		want      float64
+	}{
+		{"perfect fit", dtos.MatchBreakdown{TitleFit: 100, SkillsFit: 100, SeniorityFit: 100, LocationFit: 100, DomainFit: 100}, DefaultScoreWeights, 100},
+		{"no fit", dtos.MatchBreakdown{}, DefaultScoreWeights, 0},
+		{"default weights", dtos.MatchBreakdown{TitleFit: 80, SkillsFit: 60, SeniorityFit: 40, LocationFit: 100, DomainFit: 20}, DefaultScoreWeights, 63},
+		{"single criterion", dtos.MatchBreakdown{TitleFit: 90, SkillsFit: 10}, dtos.ScoreWeights{Skills: 1}, 10},
+		{"rounded to one decimal", dtos.MatchBreakdown{TitleFit: 100}, dtos.ScoreWeights{Title: 1.0 / 3}, 33.3},
+		{"zero weights", dtos.MatchBreakdown{TitleFit: 100, SkillsFit: 100}, dtos.ScoreWeights{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weightedScore(tt.breakdown, tt.weights); got != tt.want {
+				t.Errorf("weightedScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBatchJobEvaluation(t *testing.T) {
+	daysAgo := func(days int) *time.Time {
+		postedAt := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+		return &postedAt
+	}
+	jobs := []dtos.Job{
+		{Title: "fresh", URL: "https://jobs.example/fresh", PostedAt: daysAgo(2)},
+		{Title: "partial", URL: "https://jobs.example/partial", PostedAt: daysAgo(2)},
+		{Title: "stale", URL: "https://jobs.example/stale", PostedAt: daysAgo(60)},
+		{Title: "missing", URL: "https://jobs.example/missing"},
+		{Title: "ageing", URL: "https://jobs.example/ageing", PostedAt: daysAgo(30)},
+	}
+	fullScores := `{"title_fit": 80, "skills_fit": 80, "seniority_fit": 80, "location_fit": 80, "domain_fit": 80}`
+	response := "Here are the evaluations:\n```json\n{\"evaluations\": [" +
+		`{"job_index": 0, "match_score": 10, "reasons": "fresh", "scores": ` + fullScores + `},` +
+		`{"job_index": 1, "match_score": 65, "reasons": "partial", "scores": {"title_fit": 100, "skills_fit": 100}},` +
+		`{"job_index": 2, "match_score": 10, "reasons": "stale", "scores": ` + fullScores + `},` +
+		`{"job_index": 4, "match_score": 10, "reasons": "ageing", "scores": ` + fullScores + `},` +
+		`{"job_index": 7, "match_score": 99, "reasons": "out of range"}` +
+		"]}\n```"
+	weights := normalizeWeights(dtos.ScoreWeights{Title: 1, Skills: 1, Seniority: 1, Location: 1, Domain: 1})
+
+	rankedJobs, evaluated, err := (&RankingClient{}).parseBatchJobEvaluation(context.Background(), response, jobs, weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		title        string
+		score        float64
+		evaluated    bool
+		hasBreakdown bool
+	}{
+		{"fresh", 80, true, true},
+		{"partial", 65, true, false},
+		{"stale", 70, true, true},
+		{"missing", 50, false, false},
+		{"ageing", 74.8, true, true},
+	}
+	for i, tt := range tests {
+		rankedJob := rankedJobs[i]
+		if rankedJob.Job.Title != tt.title || rankedJob.PercentMatch != tt.score || evaluated[i] != tt.evaluated || (rankedJob.Breakdown != nil) != tt.hasBreakdown {
+			t.Errorf("job %d = %q score %v evaluated %v breakdown %v, want %q score %v evaluated %v breakdown %v",
+				i, rankedJob.Job.Title, rankedJob.PercentMatch, evaluated[i], rankedJob.Breakdown != nil,
+				tt.title, tt.score, tt.evaluated, tt.hasBreakdown)
+		}
+	}
+
+	filtered := filterAndSortRankedJobs(rankedJobs, 70)
+	var titles []string
+	for _, rankedJob := range filtered {
+		titles = append(titles, rankedJob.Job.Title)
+	}
+	want := []string{"fresh", "ageing", "stale"}
+	if len(titles) != len(want) {
+		t.Fatalf("filtered to %q, want %q", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("filtered to %q, want %q", titles, want)
+		}
+	}
+}
+
+func TestParseBatchJobEvaluationRejectsMalformedResponses(t *testing.T) {
+	jobs := []dtos.Job{{Title: "Go Developer"}}
+	for _, response := range []string{"", "no evaluations today", `{"evaluations": [}`} {
+		if _, _, err := (&RankingClient{}).parseBatchJobEvaluation(context.Background(), response, jobs, DefaultScoreWeights); err == nil {
+			t.Errorf("parseBatchJobEvaluation(%q) returned no error", response)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+)
+
+// Upper bounds for ranking options. Request validation uses the same values
+// so callers get an error instead of a silently clamped option.
+const (
+	MaxRankingJobs        = 200
+	MaxRankingBatchSize   = 25
+	MaxRankingConcurrency = 10
+	MaxAnchorJobs         = 3
+)
+
+const (
+	maxRankingDescriptionChars = 3000
+	freshPostingDays           = 14
+	stalePostingDays           = 45
+	maxRecencyPenalty          = 10
+)
+
+var DefaultScoreWeights = dtos.ScoreWeights{
+	Title:     0.25,
+	Skills:    0.30,
+	Seniority: 0.20,
+	Location:  0.15,
+	Domain:    0.10,
+}
+
+var DefaultRankingOptions = dtos.RankingOptions{
+	MaxJobs:        60,
+	BatchSize:      10,
+	MaxConcurrency: 3,
+	MinScore:       30,
+	AnchorJobs:     2,
+	PreRankTopK:    30,
+	Weights:        DefaultScoreWeights,
+}
+
+func NormalizeRankingOptions(options dtos.RankingOptions) dtos.RankingOptions {
+	if options.MaxJobs <= 0 {
+		options.MaxJobs = DefaultRankingOptions.MaxJobs
+	}
+	options.MaxJobs = min(options.MaxJobs, MaxRankingJobs)
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultRankingOptions.BatchSize
+	}
+	options.BatchSize = min(options.BatchSize, MaxRankingBatchSize)
+
+	if options.MaxConcurrency <= 0 {
+		options.MaxConcurrency = DefaultRankingOptions.MaxConcurrency
+	}
+	options.MaxConcurrency = min(options.MaxConcurrency, MaxRankingConcurrency)
+
+	options.MinScore = max(0, min(options.MinScore, 100))
+
+	if options.TopN < 0 {
+		options.TopN = 0
+	}
+
+	options.AnchorJobs = max(0, min(options.AnchorJobs, MaxAnchorJobs))
+	options.PreRankTopK = max(0, min(options.PreRankTopK, MaxRankingJobs))
+	options.Weights = normalizeWeights(options.Weights)
+
+	return options
+}
+
+func MergeRankingOptions(base dtos.RankingOptions, overrides dtos.RankingOverrides) dtos.RankingOptions {
+	if overrides.MaxJobs != nil {
+		base.MaxJobs = *overrides.MaxJobs
+	}
+	if overrides.BatchSize != nil {
+		base.BatchSize = *overrides.BatchSize
+	}
+	if overrides.MaxConcurrency != nil {
+		base.MaxConcurrency = *overrides.MaxConcurrency
+	}
+	if overrides.MinScore != nil {
+		base.MinScore = *overrides.MinScore
+	}
+	if overrides.TopN != nil {
+		base.TopN = *overrides.TopN
+	}
+	if overrides.AnchorJobs != nil {
+		base.AnchorJobs = *overrides.AnchorJobs
+	}
+	if overrides.PreRankTopK != nil {
+		base.PreRankTopK = *overrides.PreRankTopK
+	}
+	if weights := overrides.Weights; weights != nil {
+		if weights.Title != nil {
+			base.Weights.Title = *weights.Title
+		}
+		if weights.Skills != nil {
+			base.Weights.Skills = *weights.Skills
+		}
+		if weights.Seniority != nil {
+			base.Weights.Seniority = *weights.Seniority
+		}
+		if weights.Location != nil {
+			base.Weights.Location = *weights.Location
+		}
+		if weights.Domain != nil {
+			base.Weights.Domain = *weights.Domain
+		}
+	}
+	return base
+}
+
+func prepareJobsForRanking(ctx context.Context, jobs []dtos.Job, maxJobs int) []dtos.Job {
+	seen := make(map[string]bool, len(jobs)*2)
+	bySource := make(map[string][]dtos.Job)
+	var sources []string
+	kept, incomplete, duplicates := 0, 0, 0
+
+	for _, job := range jobs {
+		if strings.TrimSpace(job.Title) == "" || strings.TrimSpace(job.URL) == "" {
+			incomplete++
+			continue
+		}
+		if markSeen(seen, job) {
+			duplicates++
+			continue
+		}
+
+		if _, ok := bySource[job.Source]; !ok {
+			sources = append(sources, job.Source)
+		}
+		bySource[job.Source] = append(bySource[job.Source], job)
+		kept++
+	}
+
+	logger.FromContext(ctx).Info("prepared jobs for ranking",
+		"received", len(jobs),
+		"dropped_incomplete", incomplete,
+		"dropped_duplicates", duplicates,
+		"after_dedup", kept,
+		"max_jobs", maxJobs,
+	)
+
+	// Take jobs round-robin across sources so one fast source cannot fill the
+	// whole ranking budget before the others have returned.
+	prepared := make([]dtos.Job, 0, min(kept, maxJobs))
+	for len(prepared) < maxJobs && len(prepared) < kept {
+		for _, source := range sources {
+			if len(bySource[source]) == 0 || len(prepared) >= maxJobs {
+				continue
+			}
+			prepared = append(prepared, bySource[source][0])
+			bySource[source] = bySource[source][1:]
+		}
+	}
+
+	return prepared
+}
+
+func JobURLKey(job dtos.Job) string {
+	urlKey, _ := jobKeys(job)
+	return urlKey
+}
+
+// markSeen reports whether the job duplicates one already in seen and records
+// its keys otherwise.
+func markSeen(seen map[string]bool, job dtos.Job) bool {
+	urlKey, postingKey := jobKeys(job)
+	if seen[urlKey] || (postingKey != "" && seen[postingKey]) {
+		return true
+	}
+	seen[urlKey] = true
+	if postingKey != "" {
+		seen[postingKey] = true
+	}
+	return false
+}
+
+// jobKeys returns the URL key and the title/company/location key of a job.
+// The posting key is empty when the company is unknown, since a bare title
+// like "Software Engineer" does not identify a single posting.
+func jobKeys(job dtos.Job) (string, string) {
+	url := strings.ToLower(strings.TrimSpace(job.URL))
+	if i := strings.Index(url, "#"); i != -1 {
+		url = url[:i]
+	}
+	url = strings.TrimRight(url, "/")
+
+	title := strings.ToLower(strings.TrimSpace(job.Title))
+	company := strings.ToLower(strings.TrimSpace(job.Company))
+	if title == "" || company == "" {
+		return "url:" + url, ""
+	}
+	posting := title + "|" + company + "|" + strings.ToLower(strings.TrimSpace(job.Location))
+
+	return "url:" + url, "posting:" + posting
+}
+
+func normalizeWeights(weights dtos.ScoreWeights) dtos.ScoreWeights {
+	weights.Title = max(0, weights.Title)
+	weights.Skills = max(0, weights.Skills)
+	weights.Seniority = max(0, weights.Seniority)
+	weights.Location = max(0, weights.Location)
+	weights.Domain = max(0, weights.Domain)
+
+	total := weights.Title + weights.Skills + weights.Seniority + weights.Location + weights.Domain
+	if total == 0 {
+		return DefaultScoreWeights
+	}
+
+	return dtos.ScoreWeights{
+		Title:     weights.Title / total,
+		Skills:    weights.Skills / total,
+		Seniority: weights.Seniority / total,
+		Location:  weights.Location / total,
+		Domain:    weights.Domain / total,
+	}
+}
+
+func weightedScore(breakdown dtos.MatchBreakdown, weights dtos.ScoreWeights) float64 {
+	score := breakdown.TitleFit*weights.Title +
+		breakdown.SkillsFit*weights.Skills +
+		breakdown.SeniorityFit*weights.Seniority +
+		breakdown.LocationFit*weights.Location +
+		breakdown.DomainFit*weights.Domain
+	return math.Round(clampScore(score)*10) / 10
+}
@@ -0,0 +1,42 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestPrepareJobsForRanking(t *testing.T) {
+	jobs := []dtos.Job{
+		{Title: "Software Engineer", URL: "https://a.example/1", Source: "linkup"},
+		{Title: "Software Engineer", URL: "https://a.example/2", Source: "linkup"},
+		{Title: "Software Engineer", URL: "https://a.example/1/", Source: "jsearch"},
+		{Title: "Backend Engineer", Company: "Acme", Location: "Berlin", URL: "https://a.example/3", Source: "linkup"},
+		{Title: "backend engineer", Company: "ACME", Location: "Berlin", URL: "https://b.example/3", Source: "jsearch"},
+		{Title: "Data Engineer", Source: "linkup"},
+		{URL: "https://a.example/4", Source: "linkup"},
+	}
+
+	prepared := prepareJobsForRanking(context.Background(), jobs, 10)
+
+	var urls []string
+	for _, job := range prepared {
+		urls = append(urls, job.URL)
+	}
+	want := []string{"https://a.example/1", "https://a.example/2", "https://a.example/3"}
+	if len(urls) != len(want) {
+		t.Fatalf("prepared %q, want %q", urls, want)
+	}
+	for i := range want {
+		if urls[i] != want[i] {
+			t.Fatalf("prepared %q, want %q", urls, want)
+		}
+	}
+}
+
+func TestDefaultRankingOptionsAreWithinBounds(t *testing.T) {
+	if normalized := NormalizeRankingOptions(DefaultRankingOptions); normalized != DefaultRankingOptions {
+		t.Errorf("NormalizeRankingOptions(DefaultRankingOptions) = %+v, want unchanged", normalized)
+	}
+}
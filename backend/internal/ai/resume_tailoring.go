@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"google.golang.org/genai"
+)
+
+const maxBulletRewrites = 8
+
+// TailorResume suggests resume edits for one job. The resume PDF is sent
+// as-is when available so suggestions can quote the actual bullets;
+// otherwise the extracted profile is used. keywords are the job's required
+// skills the resume does not mention yet.
+func (p *ProfileClient) TailorResume(ctx context.Context, pdfBytes []byte, profile string, job dtos.RankedJob, keywords []string) (*dtos.ResumeTailoring, error) {
+	if p.Client == nil {
+		return nil, fmt.Errorf("gemini client is not initialised")
+	}
+
+	var parts []*genai.Part
+	if len(pdfBytes) > 0 {
+		parts = append(parts, resumePart(pdfBytes))
+	}
+	parts = append(parts, genai.NewPartFromText(p.ResumeTailoringPrompt(profile, len(pdfBytes) > 0, job, keywords)))
+
+	contents := []*genai.Content{
+		genai.NewContentFromParts(parts, genai.RoleUser),
+	}
+
+	temp := float32(0.3)
+	result, err := generateContent(ctx, p.Client, "resume_tailoring", contents, &genai.GenerateContentConfig{
+		Temperature: &temp,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate resume suggestions: %w", err)
+	}
+
+	tailoring, err := parseResumeTailoring(responseText(result))
+	if err != nil {
+		return nil, err
+	}
+	return mergeKeywordSuggestions(tailoring, keywords), nil
+}
+
+func parseResumeTailoring(responseText string) (*dtos.ResumeTailoring, error) {
+	jsonStart := strings.Index(responseText, "{")
+	jsonEnd := strings.LastIndex(responseText, "}") + 1
+	if jsonStart == -1 || jsonEnd == 0 {
+		return nil, fmt.Errorf("no JSON found in resume suggestions response")
+	}
+
+	var tailoring dtos.ResumeTailoring
+	if err := json.Unmarshal([]byte(responseText[jsonStart:jsonEnd]), &tailoring); err != nil {
+		return nil, fmt.Errorf("error parsing resume suggestions JSON: %w", err)
+	}
+
+	rewrites := []dtos.BulletRewrite{}
+	for _, rewrite := range tailoring.BulletRewrites {
+		if strings.TrimSpace(rewrite.Suggested) != "" && rewrite.Suggested != rewrite.Original {
+			rewrites = append(rewrites, rewrite)
+		}
+	}
+	tailoring.BulletRewrites = rewrites[:min(maxBulletRewrites, len(rewrites))]
+	if tailoring.SectionOrder == nil {
+		tailoring.SectionOrder = []string{}
+	}
+	return &tailoring, nil
+}
+
+// mergeKeywordSuggestions keeps the model's placement advice but makes sure
+// every missing keyword is listed exactly once, using the taxonomy's spelling.
+func mergeKeywordSuggestions(tailoring *dtos.ResumeTailoring, keywords []string) *dtos.ResumeTailoring {
+	suggestions := make(map[string]dtos.KeywordSuggestion, len(tailoring.KeywordsToAdd))
+	for _, suggestion := range tailoring.KeywordsToAdd {
+		suggestions[strings.ToLower(strings.TrimSpace(suggestion.Keyword))] = suggestion
+	}
+
+	merged := make([]dtos.KeywordSuggestion, 0, len(keywords))
+	for _, keyword := range keywords {
+		suggestion, ok := suggestions[strings.ToLower(keyword)]
+		if !ok {
+			suggestion = dtos.KeywordSuggestion{Note: "Add only if you have used it."}
+		}
+		suggestion.Keyword = keyword
+		merged = append(merged, suggestion)
+	}
+	tailoring.KeywordsToAdd = merged
+	return tailoring
+}
@@ -22,6 +22,31 @@ func (a *JobClient) Tools() []*genai.Tool {
 								- Role type (e.g., "Software Engineer", "Data Analyst")
 								Example: "Senior Python Developer" or "Junior Data Scientist"`,
 							},
+							"page": {
+								Type:        genai.TypeInteger,
+								Description: "Page of results to start from (1-5). Defaults to 1; leave unset unless earlier pages were already searched.",
+							},
+							"num_pages": {
+								Type:        genai.TypeInteger,
+								Description: "Number of result pages to return, about 10 jobs per page (1-3). Defaults to 1.",
+							},
+							"date_posted": {
+								Type:        genai.TypeString,
+								Enum:        []string{"all", "today", "3days", "week", "month"},
+								Description: "Only return jobs posted within this period.",
+							},
+							"employment_types": {
+								Type:        genai.TypeString,
+								Description: "Comma separated employment types: FULLTIME, CONTRACTOR, PARTTIME, INTERN. Example: \"FULLTIME,INTERN\" for a fresher.",
+							},
+							"job_requirements": {
+								Type:        genai.TypeString,
+								Description: "Comma separated experience requirements: under_3_years_experience, more_than_3_years_experience, no_experience, no_degree.",
+							},
+							"radius": {
+								Type:        genai.TypeNumber,
+								Description: "Distance in km from the location in the query. Only use for on-site searches that name a location.",
+							},
 						},
 						Required: []string{"query"},
 					},
@@ -0,0 +1,404 @@
+package analysis
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+)
+
+const (
+	ATSKindSkill = "skill"
+	ATSKindTerm  = "term"
+
+	RequirementSkill      = "skill"
+	RequirementExperience = "experience"
+	RequirementEducation  = "education"
+
+	maxATSTerms         = 15
+	maxHardRequirements = 20
+
+	requiredSkillWeight = 3.0
+	skillWeight         = 1.0
+	termWeight          = 0.5
+	relatedCredit       = 0.5
+)
+
+var (
+	requirementPattern    = regexp.MustCompile(`(?i)\b(required|requirements?|must|minimum|at least|mandatory|essential|need to have|you have|you'll need)\b`)
+	optionalPattern       = regexp.MustCompile(`(?i)\b(preferred|nice to have|bonus|a plus|desirable|ideally)\b`)
+	requirementHeader     = regexp.MustCompile(`(?i)^(requirements|qualifications|minimum qualifications|basic qualifications|required skills|must haves?|what you('ll)? need|what we('re)? looking for|who you are)\s*:?$`)
+	yearsPattern          = regexp.MustCompile(`(?i)\b(\d{1,2})\s*\+?\s*(?:(?:-|–|to)\s*\d{1,2}\s*\+?\s*)?years?\b`)
+	dateRangePattern      = regexp.MustCompile(`(?i)\b((?:19|20)\d{2})\s*(?:-|–|—|to)\s*((?:19|20)\d{2}|present|current|now)\b`)
+	educationLinePattern  = regexp.MustCompile(`(?i)\b(university|college|school|institute|academy|bachelor|master|degree|b\.?tech|ph\.?d)\b`)
+	equivalentPattern     = regexp.MustCompile(`(?i)\b(equivalent|or similar|or related experience)\b`)
+	genericDegreePattern  = regexp.MustCompile(`(?i)\bdegree\b`)
+	sentenceBoundaryRegex = regexp.MustCompile(`[.;!?]\s+|\n`)
+	wordPattern           = regexp.MustCompile(`[a-z][a-z0-9+#]*(?:[.-][a-z0-9+#]+)*`)
+)
+
+// Degree abbreviations are matched case-sensitively so "50 ms" or "ba" in
+// running text are not read as degrees; "MS Office" and similar product
+// names are removed before matching.
+var degreeLevels = []struct {
+	level         int
+	pattern       *regexp.Regexp
+	abbreviations *regexp.Regexp
+}{
+	{3, regexp.MustCompile(`(?i)\b(ph\.?d|doctorate|doctoral)\b`), nil},
+	{2, regexp.MustCompile(`(?i)\b(masters?|master's|msc|mba|m\.tech)\b`), regexp.MustCompile(`\b(MS|M\.S\.)(\W|$)`)},
+	{1, regexp.MustCompile(`(?i)\b(bachelors?|bachelor's|bsc|b\.tech|undergraduate)\b`), regexp.MustCompile(`\b(BS|BA|B\.S\.|B\.A\.)(\W|$)`)},
+}
+
+var msProductPattern = regexp.MustCompile(`\bMS[- ](?i:office|excel|word|powerpoint|teams|sql|project|access|outlook|dynamics|azure|windows|visio)\b`)
+
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		about above across after again against all also and any are around as at be because been before being
+		below between both but by can could did does doing down during each etc few for from further had has
+		have having her here hers him his how however if in into is it its itself just least like made make
+		many may more most much must need needs new not now of off on once only or other our ours out over own
+		per please same shall she should so some such than that the their them then there these they this
+		those through to too under until up upon using very via was we well were what when where which while
+		who whom why will with within without would you your yours yourself able ability across
+		experience experienced work working works team teams role roles job jobs position company candidate
+		candidates including include includes strong excellent good great years year plus preferred required
+		requirements requirement responsibilities responsible qualifications skills skill knowledge looking
+		join help opportunity environment based level ensure across day days time full part support new
+		understanding proven track record minimum least related field equivalent degree bachelor master
+	`) {
+		stopwords[word] = true
+	}
+}
+
+// ATSMatch scores a resume against a job the way keyword-based applicant
+// tracking systems do. It is deterministic and does not call an LLM.
+func ATSMatch(resumeText string, job dtos.Job, now time.Time) dtos.ATSReport {
+	taxonomy := skills.Default()
+	jobText := atsJobText(job)
+	sentences := requirementSentences(job)
+
+	required := make(map[string]bool)
+	for _, skill := range taxonomy.Normalize(job.RequiredSkills) {
+		required[skillKey(skill)] = true
+	}
+	for _, sentence := range sentences {
+		for _, skill := range taxonomy.Extract(sentence) {
+			required[skillKey(skill)] = true
+		}
+	}
+
+	jobSkills := taxonomy.Normalize(append(taxonomy.Extract(jobText), job.RequiredSkills...))
+	resumeSkills := taxonomy.Extract(resumeText)
+
+	report := dtos.ATSReport{
+		MatchedKeywords:  []dtos.ATSKeyword{},
+		MissingKeywords:  []dtos.ATSKeyword{},
+		HardRequirements: []dtos.ATSRequirement{},
+		ResumeWords:      len(strings.Fields(resumeText)),
+	}
+
+	var total, covered, skillCovered, termCovered float64
+	for _, skill := range jobSkills {
+		keyword := dtos.ATSKeyword{Keyword: skill, Kind: ATSKindSkill, Required: required[skillKey(skill)]}
+		weight := skillWeight
+		if keyword.Required {
+			weight = requiredSkillWeight
+		}
+		total += weight
+
+		if taxonomy.Contains(resumeText, skill) {
+			covered += weight
+			skillCovered++
+			report.MatchedKeywords = append(report.MatchedKeywords, keyword)
+			continue
+		}
+		for _, resumeSkill := range resumeSkills {
+			if taxonomy.Related(skill, resumeSkill) {
+				keyword.Related = resumeSkill
+				covered += weight * relatedCredit
+				skillCovered += relatedCredit
+				break
+			}
+		}
+		report.MissingKeywords = append(report.MissingKeywords, keyword)
+	}
+
+	terms := topTerms(jobText, maxATSTerms)
+	resumeTerms := termSet(resumeText)
+	for _, term := range terms {
+		keyword := dtos.ATSKeyword{Keyword: term, Kind: ATSKindTerm}
+		total += termWeight
+		if resumeTerms[stem(term)] {
+			covered += termWeight
+			termCovered++
+			report.MatchedKeywords = append(report.MatchedKeywords, keyword)
+		} else {
+			report.MissingKeywords = append(report.MissingKeywords, keyword)
+		}
+	}
+
+	// Required keywords first so the most important gaps are easy to spot.
+	sort.SliceStable(report.MissingKeywords, func(i, j int) bool {
+		return report.MissingKeywords[i].Required && !report.MissingKeywords[j].Required
+	})
+
+	if total > 0 {
+		report.Score = roundTenth(100 * covered / total)
+	}
+	if len(jobSkills) > 0 {
+		report.SkillCoverage = roundTenth(100 * skillCovered / float64(len(jobSkills)))
+	}
+	if len(terms) > 0 {
+		report.TermCoverage = roundTenth(100 * termCovered / float64(len(terms)))
+	}
+
+	report.HardRequirements = hardRequirements(sentences, resumeText, now)
+	for _, requirement := range report.HardRequirements {
+		if !requirement.Met {
+			report.MissingHardRequirements++
+		}
+	}
+	return report
+}
+
+func atsJobText(job dtos.Job) string {
+	parts := []string{job.Title, job.Description}
+	if job.Highlights != nil {
+		parts = append(parts, job.Highlights.Qualifications...)
+		parts = append(parts, job.Highlights.Responsibilities...)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// requirementSentences collects the job's must-have statements: JSearch
+// qualifications, lines under a requirements heading and sentences using
+// words like "required" or "must", minus the ones marked as preferred.
+func requirementSentences(job dtos.Job) []string {
+	var sentences []string
+	seen := make(map[string]bool)
+	add := func(sentence string) {
+		sentence = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(sentence), "-•*·–"))
+		if sentence == "" || seen[sentence] || optionalPattern.MatchString(sentence) || len(sentences) >= maxHardRequirements {
+			return
+		}
+		seen[sentence] = true
+		sentences = append(sentences, sentence)
+	}
+
+	if job.Highlights != nil {
+		for _, qualification := range job.Highlights.Qualifications {
+			add(qualification)
+		}
+	}
+
+	inSection := false
+	for _, line := range strings.Split(job.Description, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-•*·–"))
+		if line == "" {
+			continue
+		}
+		if requirementHeader.MatchString(line) {
+			inSection = true
+			continue
+		}
+		if strings.HasSuffix(line, ":") && len(strings.Fields(line)) <= 6 {
+			inSection = false
+			continue
+		}
+
+		for _, sentence := range sentenceBoundaryRegex.Split(line, -1) {
+			if inSection || requirementPattern.MatchString(sentence) {
+				add(sentence)
+			}
+		}
+	}
+	return sentences
+}
+
+func hardRequirements(sentences []string, resumeText string, now time.Time) []dtos.ATSRequirement {
+	taxonomy := skills.Default()
+	candidateYears := resumeYears(resumeText, now)
+	candidateDegree := degreeLevel(resumeText)
+
+	requirements := []dtos.ATSRequirement{}
+	for _, sentence := range sentences {
+		if match := yearsPattern.FindStringSubmatch(sentence); match != nil {
+			years, _ := strconv.Atoi(match[1])
+			if years > 0 && years <= 30 {
+				requirement := dtos.ATSRequirement{
+					Requirement: sentence,
+					Type:        RequirementExperience,
+					Met:         candidateYears >= years,
+				}
+				if !requirement.Met {
+					requirement.Missing = []string{strconv.Itoa(years) + "+ years"}
+				}
+				requirements = append(requirements, requirement)
+			}
+		}
+
+		level := degreeLevel(sentence)
+		if level == 0 && genericDegreePattern.MatchString(sentence) {
+			level = 1
+		}
+		if level > 0 {
+			requirement := dtos.ATSRequirement{
+				Requirement: sentence,
+				Type:        RequirementEducation,
+				Met:         candidateDegree >= level || equivalentPattern.MatchString(sentence),
+			}
+			if !requirement.Met {
+				requirement.Missing = []string{degreeName(level)}
+			}
+			requirements = append(requirements, requirement)
+		}
+
+		if found := taxonomy.Extract(sentence); len(found) > 0 {
+			requirement := dtos.ATSRequirement{Requirement: sentence, Type: RequirementSkill}
+			for _, skill := range found {
+				if !taxonomy.Contains(resumeText, skill) {
+					requirement.Missing = append(requirement.Missing, skill)
+				}
+			}
+			requirement.Met = len(requirement.Missing) == 0
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
+
+// resumeYears estimates professional experience from explicit "N years"
+// statements and from merged date ranges on non-education lines.
+func resumeYears(text string, now time.Time) int {
+	best := 0
+	var spans [][2]int
+	for _, line := range strings.Split(text, "\n") {
+		if educationLinePattern.MatchString(line) {
+			continue
+		}
+		for _, match := range yearsPattern.FindAllStringSubmatch(line, -1) {
+			if years, _ := strconv.Atoi(match[1]); years <= 50 {
+				best = max(best, years)
+			}
+		}
+		for _, match := range dateRangePattern.FindAllStringSubmatch(line, -1) {
+			start, _ := strconv.Atoi(match[1])
+			end, err := strconv.Atoi(match[2])
+			if err != nil {
+				end = now.Year()
+			}
+			if end >= start && start <= now.Year() {
+				spans = append(spans, [2]int{start, min(end, now.Year())})
+			}
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i][0] < spans[j][0] })
+	total, currentStart, currentEnd := 0, -1, -1
+	for _, span := range spans {
+		if currentEnd < 0 || span[0] > currentEnd {
+			if currentEnd >= 0 {
+				total += currentEnd - currentStart
+			}
+			currentStart, currentEnd = span[0], span[1]
+			continue
+		}
+		currentEnd = max(currentEnd, span[1])
+	}
+	if currentEnd >= 0 {
+		total += currentEnd - currentStart
+	}
+	return max(best, total)
+}
+
+func degreeLevel(text string) int {
+	text = msProductPattern.ReplaceAllString(text, "")
+	for _, degree := range degreeLevels {
+		if degree.pattern.MatchString(text) || (degree.abbreviations != nil && degree.abbreviations.MatchString(text)) {
+			return degree.level
+		}
+	}
+	return 0
+}
+
+func degreeName(level int) string {
+	switch level {
+	case 3:
+		return "PhD"
+	case 2:
+		return "Master's degree"
+	default:
+		return "Bachelor's degree"
+	}
+}
+
+// topTerms returns the job's most repeated non-skill words, the generic
+// keywords an ATS also matches on.
+func topTerms(text string, limit int) []string {
+	taxonomy := skills.Default()
+	counts := make(map[string]int)
+	forms := make(map[string]string)
+	for _, word := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		if len(word) < 3 || stopwords[word] || stopwords[stem(word)] {
+			continue
+		}
+		if _, isSkill := taxonomy.Lookup(word); isSkill {
+			continue
+		}
+		key := stem(word)
+		counts[key]++
+		if _, ok := forms[key]; !ok {
+			forms[key] = word
+		}
+	}
+
+	terms := make([]string, 0, len(counts))
+	for key, count := range counts {
+		if count >= 2 {
+			terms = append(terms, key)
+		}
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if counts[terms[i]] != counts[terms[j]] {
+			return counts[terms[i]] > counts[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+
+	terms = terms[:min(limit, len(terms))]
+	for i, key := range terms {
+		terms[i] = forms[key]
+	}
+	return terms
+}
+
+func termSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range wordPattern.FindAllString(strings.ToLower(text), -1) {
+		set[stem(word)] = true
+	}
+	return set
+}
+
+// stem folds simple plurals so "services" matches "service".
+func stem(word string) string {
+	switch {
+	case len(word) > 4 && strings.HasSuffix(word, "ies"):
+		return word[:len(word)-3] + "y"
+	case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return word[:len(word)-1]
+	}
+	return word
+}
+
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,144 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+var testNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDegreeLevel(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"p99 latency under 50 ms", 0},
+		{"Must know MS Office and MS-Excel", 0},
+		{"ms sql server experience", 0},
+		{"ba in anything", 0},
+		{"MS in Computer Science", 2},
+		{"BS/MS in a technical field", 2},
+		{"M.S. Computer Science, 2018", 2},
+		{"Master's degree required", 2},
+		{"BS degree in Engineering", 1},
+		{"Bachelor of Technology", 1},
+		{"PhD in Machine Learning", 3},
+		{"Senior Go engineer", 0},
+	}
+
+	for _, tt := range tests {
+		if got := degreeLevel(tt.text); got != tt.want {
+			t.Errorf("degreeLevel(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestResumeYears(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"explicit statement", "Backend engineer with 7+ years of experience", 7},
+		{"merged date ranges", "Acme Corp 2015 - 2019\nGlobex 2018 - 2021", 6},
+		{"disjoint date ranges", "Acme Corp 2010 - 2012\nGlobex 2020 - 2022", 4},
+		{"open-ended range", "Initech 2021 - present", 4},
+		{"education lines skipped", "State University 2008 - 2012\nInitech 2022 - 2024", 2},
+		{"explicit beats ranges", "10 years building APIs\nInitech 2022 - 2024", 10},
+		{"future start ignored", "Hooli 2030 - present", 0},
+		{"nothing to go on", "Go, Kubernetes, PostgreSQL", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resumeYears(tt.text, testNow); got != tt.want {
+				t.Errorf("resumeYears() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirementSentences(t *testing.T) {
+	job := dtos.Job{
+		Description: "About us:\nWe build payment systems.\n" +
+			"Requirements:\n- 5+ years of Go\n- Experience with Kubernetes\n- Kafka is a plus\n" +
+			"Benefits:\nFree lunch. You must enjoy snacks.",
+		Highlights: &dtos.JobHighlights{
+			Qualifications: []string{"BS in Computer Science", "- 5+ years of Go", "GraphQL preferred"},
+		},
+	}
+
+	want := []string{
+		"BS in Computer Science",
+		"5+ years of Go",
+		"Experience with Kubernetes",
+		"You must enjoy snacks.",
+	}
+	if got := requirementSentences(job); !reflect.DeepEqual(got, want) {
+		t.Errorf("requirementSentences() = %q, want %q", got, want)
+	}
+}
+
+func TestATSMatch(t *testing.T) {
+	job := dtos.Job{
+		Title:          "Backend Engineer",
+		Description:    "Requirements:\n5+ years of experience with Go\nMS in Computer Science\nKeep p99 latency under 50 ms.",
+		RequiredSkills: []string{"Kubernetes"},
+	}
+	resume := "Backend engineer. Go and Kubernetes.\nAcme Corp 2016 - present\nBachelor of Science, State University"
+
+	report := ATSMatch(resume, job, testNow)
+
+	if report.Score <= 0 || report.Score > 100 {
+		t.Errorf("Score = %v, want within (0, 100]", report.Score)
+	}
+	if report.SkillCoverage != 100 {
+		t.Errorf("SkillCoverage = %v, want 100", report.SkillCoverage)
+	}
+	if report.ResumeWords != 15 {
+		t.Errorf("ResumeWords = %d, want 15", report.ResumeWords)
+	}
+	for _, keyword := range report.MatchedKeywords {
+		if keyword.Keyword == "Kubernetes" && !keyword.Required {
+			t.Errorf("Kubernetes should be a required keyword")
+		}
+	}
+
+	type requirement struct {
+		Type string
+		Met  bool
+	}
+	var got []requirement
+	for _, r := range report.HardRequirements {
+		got = append(got, requirement{r.Type, r.Met})
+	}
+	want := []requirement{
+		{RequirementExperience, true},
+		{RequirementSkill, true},
+		{RequirementEducation, false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HardRequirements = %+v, want %+v", report.HardRequirements, want)
+	}
+	if report.MissingHardRequirements != 1 {
+		t.Errorf("MissingHardRequirements = %d, want 1", report.MissingHardRequirements)
+	}
+}
+
+func TestATSMatchIsDeterministic(t *testing.T) {
+	job := dtos.Job{
+		Title:       "Platform Engineer",
+		Description: "Must have Terraform, AWS and Python. Docker preferred. Build internal tooling for developers.",
+	}
+	resume := "Python developer with AWS and Docker experience"
+
+	first := ATSMatch(resume, job, testNow)
+	for range 5 {
+		if next := ATSMatch(resume, job, testNow); !reflect.DeepEqual(first, next) {
+			t.Fatalf("ATSMatch is not deterministic:\n%+v\n%+v", first, next)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package analysis
+
+import (
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+)
+
+// KeywordsToAdd returns the job's required skills, in canonical form, that
+// the ranking did not match and that do not appear in the resume text. The
+// text check is skipped when resumeText is empty.
+func KeywordsToAdd(rankedJob dtos.RankedJob, resumeText string) []string {
+	taxonomy := skills.Default()
+
+	required := append([]string{}, rankedJob.Job.RequiredSkills...)
+	required = append(required, rankedJob.MissingSkills...)
+	if rankedJob.Job.Highlights != nil {
+		required = append(required, taxonomy.Extract(strings.Join(rankedJob.Job.Highlights.Qualifications, "\n"))...)
+	}
+
+	matched := make(map[string]bool)
+	for _, skill := range taxonomy.Normalize(rankedJob.SkillsMatched) {
+		matched[skillKey(skill)] = true
+	}
+
+	keywords := []string{}
+	for _, skill := range taxonomy.Normalize(required) {
+		if matched[skillKey(skill)] {
+			continue
+		}
+		if resumeText != "" && taxonomy.Contains(resumeText, skill) {
+			continue
+		}
+		keywords = append(keywords, skill)
+	}
+	return keywords
+}
@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+)
+
+const maxGapExamples = 3
+
+func SkillGaps(run *dtos.SearchRun, limit int) dtos.SkillGapReport {
+	vocabulary := buildVocabulary(run.Jobs)
+	candidateSkills := candidateSkillSet(run, vocabulary)
+
+	type gap struct {
+		skill    string
+		count    int
+		weighted float64
+		examples []dtos.SkillGapExample
+	}
+	gaps := make(map[string]*gap)
+
+	for _, rankedJob := range run.Jobs {
+		weight := rankedJob.PercentMatch / 100
+
+		for key, skill := range jobSkills(rankedJob, vocabulary) {
+			if candidateSkills[key] != "" {
+				continue
+			}
+
+			entry, ok := gaps[key]
+			if !ok {
+				entry = &gap{skill: skill}
+				gaps[key] = entry
+			}
+			entry.count++
+			entry.weighted += weight
+			entry.examples = append(entry.examples, dtos.SkillGapExample{
+				Title:        rankedJob.Job.Title,
+				Company:      rankedJob.Job.Company,
+				URL:          rankedJob.Job.URL,
+				PercentMatch: rankedJob.PercentMatch,
+			})
+		}
+	}
+
+	report := dtos.SkillGapReport{
+		RunID:           run.ID,
+		JobsAnalyzed:    len(run.Jobs),
+		CandidateSkills: sortedValues(candidateSkills),
+		Gaps:            []dtos.SkillGap{},
+		Success:         true,
+	}
+
+	for _, entry := range gaps {
+		sort.SliceStable(entry.examples, func(i, j int) bool {
+			return entry.examples[i].PercentMatch > entry.examples[j].PercentMatch
+		})
+		report.Gaps = append(report.Gaps, dtos.SkillGap{
+			Skill:         entry.skill,
+			MissingIn:     entry.count,
+			WeightedScore: math.Round(entry.weighted*100) / 100,
+			ExampleJobs:   entry.examples[:min(maxGapExamples, len(entry.examples))],
+		})
+	}
+
+	sort.Slice(report.Gaps, func(i, j int) bool {
+		if report.Gaps[i].WeightedScore != report.Gaps[j].WeightedScore {
+			return report.Gaps[i].WeightedScore > report.Gaps[j].WeightedScore
+		}
+		return report.Gaps[i].Skill < report.Gaps[j].Skill
+	})
+
+	if limit > 0 && len(report.Gaps) > limit {
+		report.Gaps = report.Gaps[:limit]
+	}
+
+	return report
+}
+
+func buildVocabulary(rankedJobs []dtos.RankedJob) map[string]string {
+	taxonomy := skills.Default()
+	vocabulary := make(map[string]string)
+	for _, rankedJob := range rankedJobs {
+		for _, list := range [][]string{rankedJob.Job.RequiredSkills, rankedJob.SkillsMatched, rankedJob.MissingSkills} {
+			for _, skill := range taxonomy.Normalize(list) {
+				if _, ok := vocabulary[skillKey(skill)]; !ok {
+					vocabulary[skillKey(skill)] = skill
+				}
+			}
+		}
+	}
+	return vocabulary
+}
+
+func candidateSkillSet(run *dtos.SearchRun, vocabulary map[string]string) map[string]string {
+	taxonomy := skills.Default()
+	candidate := make(map[string]string)
+	for _, skill := range taxonomy.Extract(run.Profile) {
+		candidate[skillKey(skill)] = skill
+	}
+	for key, skill := range vocabulary {
+		if _, known := taxonomy.Lookup(skill); !known && taxonomy.Contains(run.Profile, skill) {
+			candidate[key] = skill
+		}
+	}
+	for _, rankedJob := range run.Jobs {
+		for _, skill := range taxonomy.Normalize(rankedJob.SkillsMatched) {
+			candidate[skillKey(skill)] = skill
+		}
+	}
+	delete(candidate, "")
+	return candidate
+}
+
+func jobSkills(rankedJob dtos.RankedJob, vocabulary map[string]string) map[string]string {
+	taxonomy := skills.Default()
+	required := make(map[string]string)
+	for _, list := range [][]string{rankedJob.Job.RequiredSkills, rankedJob.MissingSkills} {
+		for _, skill := range taxonomy.Normalize(list) {
+			required[skillKey(skill)] = skill
+		}
+	}
+	if rankedJob.Job.Description != "" {
+		for _, skill := range taxonomy.Extract(rankedJob.Job.Description) {
+			required[skillKey(skill)] = skill
+		}
+		for key, skill := range vocabulary {
+			if _, known := taxonomy.Lookup(skill); !known && taxonomy.Contains(rankedJob.Job.Description, skill) {
+				required[key] = skill
+			}
+		}
+	}
+	delete(required, "")
+	return required
+}
+
+func skillKey(skill string) string {
+	return strings.ToLower(strings.TrimSpace(skill))
+}
+
+func sortedValues(values map[string]string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result
+}
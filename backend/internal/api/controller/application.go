@@ -0,0 +1,394 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/api/service"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+const maxApplicationNotesLength = 10000
+
+var invalidApplicationStatusMessage = "status must be one of " + strings.Join(service.ApplicationStatuses, ", ")
+
+func (c *JobController) CreateApplication(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	var request dtos.CreateApplicationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Invalid request body: " + err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if message := validateCreateApplication(request); message != "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     message,
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	application, err := c.service.CreateApplication(ctx.Request.Context(), ownerToken, request)
+	switch {
+	case errors.Is(err, service.ErrInvalidOwnerToken):
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, repo.ErrAlreadyExists):
+		ctx.JSON(http.StatusConflict, dtos.ErrorResponse{
+			Error:     "Job is already on the application board",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, repo.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Search run not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, service.ErrJobNotInRun):
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Job not found in search run",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, application)
+}
+
+func (c *JobController) ListApplications(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	status := ctx.Query("status")
+	if status != "" && !service.ValidApplicationStatus(status) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     invalidApplicationStatusMessage,
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	applications, err := c.service.ListApplications(ctx.Request.Context(), ownerToken, status, ctx.Query("tag"))
+	if err != nil {
+		respondApplicationError(ctx, err)
+		return
+	}
+
+	byStatus := make(map[string]int, len(service.ApplicationStatuses))
+	for _, application := range applications {
+		byStatus[application.Status]++
+	}
+
+	ctx.JSON(http.StatusOK, dtos.ApplicationListResponse{
+		Applications: applications,
+		Total:        len(applications),
+		ByStatus:     byStatus,
+		Success:      true,
+	})
+}
+
+func (c *JobController) GetApplication(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	application, err := c.service.GetApplication(ctx.Request.Context(), ownerToken, ctx.Param("application_id"))
+	if err != nil {
+		respondApplicationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, application)
+}
+
+func (c *JobController) UpdateApplication(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	var request dtos.UpdateApplicationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Invalid request body: " + err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if request.Status != nil && !service.ValidApplicationStatus(*request.Status) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     invalidApplicationStatusMessage,
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if request.Notes != nil && len(*request.Notes) > maxApplicationNotesLength {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "notes must be at most 10000 characters",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	application, err := c.service.UpdateApplication(ctx.Request.Context(), ownerToken, ctx.Param("application_id"), request)
+	if err != nil {
+		respondApplicationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, application)
+}
+
+func (c *JobController) DeleteApplication(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteApplication(ctx.Request.Context(), ownerToken, ctx.Param("application_id")); err != nil {
+		respondApplicationError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func validateCreateApplication(request dtos.CreateApplicationRequest) string {
+	if request.Job == nil && (request.RunID == "" || request.JobURL == "") {
+		return "Either job or both run_id and job_url are required"
+	}
+	if request.Job != nil {
+		jobURL := strings.TrimSpace(request.Job.Job.URL)
+		if jobURL == "" {
+			return "job.job.url is required"
+		}
+		parsed, err := url.Parse(jobURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || strings.ContainsFunc(jobURL, unicode.IsControl) {
+			return "job.job.url must be an http or https URL"
+		}
+	}
+	if request.Status != "" && !service.ValidApplicationStatus(request.Status) {
+		return invalidApplicationStatusMessage
+	}
+	if len(request.Notes) > maxApplicationNotesLength {
+		return "notes must be at most 10000 characters"
+	}
+	return ""
+}
+
+func respondApplicationError(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidOwnerToken) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if errors.Is(err, repo.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Application not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+		Error:     err.Error(),
+		Success:   false,
+		Timestamp: time.Now(),
+	})
+}
+
+func (c *JobController) GenerateCoverLetter(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	apiKey := ctx.PostForm("api_key")
+	if apiKey == "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Gemini API key is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	options := dtos.CoverLetterOptions{
+		Tone:   ctx.DefaultPostForm("tone", ai.CoverLetterToneProfessional),
+		Length: ctx.DefaultPostForm("length", ai.CoverLetterLengthMedium),
+	}
+	if !slices.Contains(ai.CoverLetterTones, options.Tone) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "tone must be one of " + strings.Join(ai.CoverLetterTones, ", "),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if !ai.ValidCoverLetterLength(options.Length) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "length must be short, medium or long",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	source, ok := parseProfileSource(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.GenerateCoverLetter(ctx.Request.Context(), ownerToken, ctx.Param("application_id"), source, options, apiKey)
+	if err != nil {
+		respondApplicationDocumentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// parseProfileSource reads the optional run_id and resume fields used when an
+// application has no stored candidate profile.
+func parseProfileSource(ctx *gin.Context) (service.ProfileSource, bool) {
+	source := service.ProfileSource{RunID: ctx.PostForm("run_id")}
+	if _, err := ctx.FormFile("resume"); err != nil {
+		return source, true
+	}
+
+	pdfBytes, ok := readResumePDF(ctx)
+	if !ok {
+		return source, false
+	}
+	source.PDFBytes = pdfBytes
+	return source, true
+}
+
+func respondApplicationDocumentError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrProfileRequired), errors.Is(err, service.ErrInvalidOwnerToken):
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+	case errors.Is(err, repo.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Application or search run not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+func (c *JobController) TailorResume(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	apiKey := ctx.PostForm("api_key")
+	if apiKey == "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Gemini API key is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	source, ok := parseProfileSource(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.TailorResume(ctx.Request.Context(), ownerToken, ctx.Param("application_id"), source, apiKey)
+	if err != nil {
+		respondApplicationDocumentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *JobController) GenerateInterviewPrep(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	apiKey := ctx.PostForm("api_key")
+	if apiKey == "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Gemini API key is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	source, ok := parseProfileSource(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.service.GenerateInterviewPrep(ctx.Request.Context(), ownerToken, ctx.Param("application_id"), source, apiKey)
+	if err != nil {
+		respondApplicationDocumentError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
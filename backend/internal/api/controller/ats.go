@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/api/service"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+const maxResumeTextLength = 100000
+
+func (c *JobController) ATSReport(ctx *gin.Context) {
+	input := service.ATSInput{
+		ResumeText:    ctx.PostForm("resume_text"),
+		OwnerToken:    strings.TrimSpace(ctx.GetHeader(OwnerTokenHeader)),
+		ApplicationID: ctx.PostForm("application_id"),
+		RunID:         ctx.PostForm("run_id"),
+		JobURL:        ctx.PostForm("job_url"),
+	}
+
+	if input.ApplicationID == "" && input.RunID == "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Either application_id or run_id is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if len(input.ResumeText) > maxResumeTextLength {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "resume_text must be at most 100000 characters",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if input.ResumeText == "" {
+		pdfBytes, ok := readResumePDF(ctx)
+		if !ok {
+			return
+		}
+		input.ResumePDF = pdfBytes
+	}
+
+	reports, err := c.service.ATSReports(ctx.Request.Context(), input)
+	switch {
+	case errors.Is(err, service.ErrInvalidOwnerToken):
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, service.ErrResumeUnreadable):
+		ctx.JSON(http.StatusUnprocessableEntity, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, repo.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Application or search run not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, service.ErrJobNotInRun):
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Job not found in search run",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.ATSReportResponse{
+		Reports: reports,
+		Total:   len(reports),
+		Success: true,
+	})
+}
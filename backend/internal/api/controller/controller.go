@@ -1,11 +1,18 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/job-assistance/config"
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
 	"github.com/lakshya1goel/job-assistance/internal/api/service"
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
 )
@@ -14,34 +21,227 @@ type JobController struct {
 	service service.JobService
 }
 
-func NewJobController() *JobController {
+func NewJobController(jobService service.JobService) *JobController {
 	return &JobController{
-		service: service.NewJobService(),
+		service: jobService,
 	}
 }
 
 func (c *JobController) FetchStructuredJobs(ctx *gin.Context) {
-	file, header, err := ctx.Request.FormFile("resume")
-	if err != nil {
+	pdfBytes, ok := readResumePDF(ctx)
+	if !ok {
+		return
+	}
+
+	apiKey := ctx.PostForm("api_key")
+	if apiKey == "" {
 		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
-			Error:     "Resume PDF file is required",
+			Error:     "Gemini API key is required",
 			Success:   false,
 			Timestamp: time.Now(),
 		})
 		return
 	}
-	defer file.Close()
 
-	apiKey := ctx.PostForm("api_key")
-	if apiKey == "" {
+	request, err := parseSearchRequest(ctx)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
-			Error:     "Gemini API key is required",
+			Error:     err.Error(),
 			Success:   false,
 			Timestamp: time.Now(),
 		})
 		return
 	}
 
+	run, usageSummary, err := c.service.FetchAndRankStructuredJobs(ctx.Request.Context(), strings.TrimSpace(ctx.GetHeader(OwnerTokenHeader)), pdfBytes, request, apiKey)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	response := dtos.JobSearchResponse{
+		RunID:    run.ID,
+		Jobs:     run.Jobs,
+		Total:    len(run.Jobs),
+		Filtered: run.Filtered,
+		Usage:    usageSummary,
+		Success:  true,
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *JobController) GetSkillGaps(ctx *gin.Context) {
+	limit := 10
+	if value := ctx.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 100 {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error:     "limit must be an integer between 1 and 100",
+				Success:   false,
+				Timestamp: time.Now(),
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	report, err := c.service.GetSkillGaps(ctx.Request.Context(), ctx.Param("run_id"), limit)
+	if errors.Is(err, repo.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Search run not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func parseRankingOverrides(ctx *gin.Context) (dtos.RankingOverrides, error) {
+	var overrides dtos.RankingOverrides
+
+	intFields := []struct {
+		name   string
+		target **int
+		min    int
+		max    int
+	}{
+		{"max_jobs", &overrides.MaxJobs, 1, ai.MaxRankingJobs},
+		{"batch_size", &overrides.BatchSize, 1, ai.MaxRankingBatchSize},
+		{"concurrency", &overrides.MaxConcurrency, 1, ai.MaxRankingConcurrency},
+		{"top_n", &overrides.TopN, 1, ai.MaxRankingJobs},
+		{"anchor_jobs", &overrides.AnchorJobs, 0, ai.MaxAnchorJobs},
+		{"prerank_top_k", &overrides.PreRankTopK, 0, ai.MaxRankingJobs},
+	}
+	for _, field := range intFields {
+		value := ctx.PostForm(field.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < field.min || parsed > field.max {
+			return overrides, fmt.Errorf("%s must be an integer between %d and %d", field.name, field.min, field.max)
+		}
+		*field.target = &parsed
+	}
+
+	if value := ctx.PostForm("min_score"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > 100 {
+			return overrides, fmt.Errorf("min_score must be a number between 0 and 100")
+		}
+		overrides.MinScore = &parsed
+	}
+
+	var weights dtos.ScoreWeightOverrides
+	weightFields := []struct {
+		name   string
+		target **float64
+	}{
+		{"weight_title", &weights.Title},
+		{"weight_skills", &weights.Skills},
+		{"weight_seniority", &weights.Seniority},
+		{"weight_location", &weights.Location},
+		{"weight_domain", &weights.Domain},
+	}
+	for _, field := range weightFields {
+		value := ctx.PostForm(field.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			return overrides, fmt.Errorf("%s must be a non-negative number", field.name)
+		}
+		*field.target = &parsed
+		overrides.Weights = &weights
+	}
+
+	return overrides, nil
+}
+
+func parseJobFilters(ctx *gin.Context) (dtos.JobFilters, error) {
+	var filters dtos.JobFilters
+
+	if value := ctx.PostForm("max_age_days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 365 {
+			return filters, fmt.Errorf("max_age_days must be an integer between 1 and 365")
+		}
+		filters.MaxAgeDays = parsed
+	}
+
+	if value := ctx.PostForm("min_salary"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			return filters, fmt.Errorf("min_salary must be a non-negative annual amount")
+		}
+		filters.MinSalary = parsed
+		filters.Currency = strings.ToUpper(ctx.DefaultPostForm("currency", "USD"))
+		if _, ok := config.GetFXRates()[filters.Currency]; !ok {
+			return filters, fmt.Errorf("unsupported currency %q", filters.Currency)
+		}
+	}
+
+	filters.ExcludeCompanies = postFormList(ctx, "exclude_companies")
+	filters.ExcludeTitleKeywords = postFormList(ctx, "exclude_titles")
+	filters.ExcludeKeywords = postFormList(ctx, "exclude_keywords")
+	filters.ExcludeDomains = postFormList(ctx, "exclude_domains")
+	filters.RequiredSkills = postFormList(ctx, "required_skills")
+	filters.EmploymentTypes = postFormList(ctx, "employment_types")
+
+	validEmploymentTypes := map[string]bool{"FULLTIME": true, "CONTRACTOR": true, "PARTTIME": true, "INTERN": true}
+	for i, employmentType := range filters.EmploymentTypes {
+		filters.EmploymentTypes[i] = strings.ToUpper(strings.NewReplacer("-", "", "_", "", " ", "").Replace(employmentType))
+		if !validEmploymentTypes[filters.EmploymentTypes[i]] {
+			return filters, fmt.Errorf("invalid employment type %q. Must be FULLTIME, CONTRACTOR, PARTTIME or INTERN", employmentType)
+		}
+	}
+
+	return filters, nil
+}
+
+// postFormList accepts both repeated form fields and comma separated values.
+func postFormList(ctx *gin.Context, name string) []string {
+	var values []string
+	for _, field := range ctx.PostFormArray(name) {
+		for _, value := range strings.Split(field, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// readResumePDF validates and reads the uploaded resume, writing the error
+// response itself when the upload is missing or invalid.
+func readResumePDF(ctx *gin.Context) ([]byte, bool) {
+	file, header, err := ctx.Request.FormFile("resume")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Resume PDF file is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return nil, false
+	}
+	defer file.Close()
+
 	contentType := header.Header.Get("Content-Type")
 	if contentType != "application/pdf" {
 		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
@@ -49,7 +249,7 @@ func (c *JobController) FetchStructuredJobs(ctx *gin.Context) {
 			Success:   false,
 			Timestamp: time.Now(),
 		})
-		return
+		return nil, false
 	}
 
 	if header.Size > 10*1024*1024 {
@@ -58,7 +258,7 @@ func (c *JobController) FetchStructuredJobs(ctx *gin.Context) {
 			Success:   false,
 			Timestamp: time.Now(),
 		})
-		return
+		return nil, false
 	}
 
 	pdfBytes, err := io.ReadAll(file)
@@ -68,9 +268,13 @@ func (c *JobController) FetchStructuredJobs(ctx *gin.Context) {
 			Success:   false,
 			Timestamp: time.Now(),
 		})
-		return
+		return nil, false
 	}
 
+	return pdfBytes, true
+}
+
+func parseSearchRequest(ctx *gin.Context) (dtos.JobSearchRequest, error) {
 	locationTypes := ctx.PostFormArray("location_types")
 	locations := ctx.PostFormArray("locations")
 
@@ -97,12 +301,7 @@ func (c *JobController) FetchStructuredJobs(ctx *gin.Context) {
 	validTypes := map[string]bool{"remote": true, "onsite": true, "hybrid": true}
 	for _, locType := range locationPreference.Types {
 		if !validTypes[locType] {
-			ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
-				Error:     "Invalid location type. Must be 'remote', 'onsite', or 'hybrid'",
-				Success:   false,
-				Timestamp: time.Now(),
-			})
-			return
+			return dtos.JobSearchRequest{}, fmt.Errorf("Invalid location type. Must be 'remote', 'onsite', or 'hybrid'")
 		}
 	}
 
@@ -115,29 +314,22 @@ func (c *JobController) FetchStructuredJobs(ctx *gin.Context) {
 	}
 
 	if needsLocation && len(locationPreference.Locations) == 0 {
-		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
-			Error:     "At least one location is required for onsite and hybrid positions",
-			Success:   false,
-			Timestamp: time.Now(),
-		})
-		return
+		return dtos.JobSearchRequest{}, fmt.Errorf("At least one location is required for onsite and hybrid positions")
 	}
 
-	rankedJobs, err := c.service.FetchAndRankStructuredJobs(ctx.Request.Context(), pdfBytes, locationPreference, apiKey)
+	rankingOverrides, err := parseRankingOverrides(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
-			Error:     err.Error(),
-			Success:   false,
-			Timestamp: time.Now(),
-		})
-		return
+		return dtos.JobSearchRequest{}, err
 	}
 
-	response := dtos.JobSearchResponse{
-		Jobs:    rankedJobs,
-		Total:   len(rankedJobs),
-		Success: true,
+	filters, err := parseJobFilters(ctx)
+	if err != nil {
+		return dtos.JobSearchRequest{}, err
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	return dtos.JobSearchRequest{
+		LocationPreference: locationPreference,
+		Ranking:            rankingOverrides,
+		Filters:            filters,
+	}, nil
 }
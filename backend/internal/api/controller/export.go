@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/job-assistance/config"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/api/service"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/export"
+)
+
+func (c *JobController) ExportRun(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", export.FormatCSV)
+	if format != export.FormatCSV && format != export.FormatJSONL {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "format must be csv or jsonl",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	runID := ctx.Param("run_id")
+	run, err := c.service.GetSearchRun(ctx.Request.Context(), runID)
+	if errors.Is(err, repo.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Search run not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	if format == export.FormatCSV {
+		err = export.RankedJobsCSV(&buf, run.Jobs)
+	} else {
+		err = export.JSONLines(&buf, run.Jobs)
+	}
+	writeExport(ctx, "run-"+runID, format, buf.Bytes(), err)
+}
+
+func (c *JobController) ExportApplications(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	format := ctx.DefaultQuery("format", export.FormatCSV)
+	if _, ok := export.ContentTypes[format]; !ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "format must be csv, jsonl or ics",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	status := ctx.Query("status")
+	if status != "" && !service.ValidApplicationStatus(status) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     invalidApplicationStatusMessage,
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	applications, err := c.service.ListApplications(ctx.Request.Context(), ownerToken, status, ctx.Query("tag"))
+	if err != nil {
+		respondApplicationError(ctx, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case export.FormatCSV:
+		err = export.ApplicationsCSV(&buf, applications)
+	case export.FormatJSONL:
+		err = export.JSONLines(&buf, applications)
+	case export.FormatICS:
+		// Only jobs that are still waiting on the employer need a reminder.
+		pending := []dtos.Application{}
+		for _, application := range applications {
+			if application.Status == service.ApplicationStatusApplied || application.Status == service.ApplicationStatusInterviewing {
+				pending = append(pending, application)
+			}
+		}
+		err = export.FollowUpCalendar(&buf, pending, config.GetFollowUpDays(), time.Now())
+	}
+	writeExport(ctx, "applications", format, buf.Bytes(), err)
+}
+
+func writeExport(ctx *gin.Context, name, format string, body []byte, err error) {
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     "Failed to export: " + err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+format))
+	ctx.Data(http.StatusOK, export.ContentTypes[format], body)
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/api/service"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/netguard"
+)
+
+const maxJobTextLength = 50000
+
+func (c *JobController) ScoreJob(ctx *gin.Context) {
+	input := service.JobScoreInput{
+		URL:        strings.TrimSpace(ctx.PostForm("job_url")),
+		Text:       strings.TrimSpace(ctx.PostForm("job_text")),
+		APIKey:     ctx.PostForm("api_key"),
+		OwnerToken: strings.TrimSpace(ctx.GetHeader(OwnerTokenHeader)),
+	}
+
+	if input.APIKey == "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Gemini API key is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if (input.URL == "") == (input.Text == "") {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Exactly one of job_url or job_text is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if input.URL != "" {
+		parsed, err := url.Parse(input.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error:     "job_url must be an http or https URL",
+				Success:   false,
+				Timestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	if len(input.Text) > maxJobTextLength {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "job_text must be at most 50000 characters",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	source, ok := parseProfileSource(ctx)
+	if !ok {
+		return
+	}
+	if source.RunID == "" && len(source.PDFBytes) == 0 {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Either run_id or a resume is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	input.Profile = source
+
+	response, err := c.service.ScoreJob(ctx.Request.Context(), input)
+	switch {
+	case errors.Is(err, netguard.ErrBlockedAddress):
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "job_url must point to a public address",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, service.ErrJobPageUnavailable):
+		ctx.JSON(http.StatusBadGateway, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, service.ErrNoJobPosting):
+		ctx.JSON(http.StatusUnprocessableEntity, dtos.ErrorResponse{
+			Error:     "No job posting found at job_url",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case errors.Is(err, repo.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Search run not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+const maxProfileTextLength = 20000
+
+func (c *JobController) RankJobs(ctx *gin.Context) {
+	var request dtos.RankJobsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Invalid request body: " + err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if err := validateRankJobsRequest(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	run, usageSummary, err := c.service.RankJobs(ctx.Request.Context(), strings.TrimSpace(ctx.GetHeader(OwnerTokenHeader)), request)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.JobSearchResponse{
+		RunID:   run.ID,
+		Jobs:    run.Jobs,
+		Total:   len(run.Jobs),
+		Usage:   usageSummary,
+		Success: true,
+	})
+}
+
+func validateRankJobsRequest(request *dtos.RankJobsRequest) error {
+	if request.APIKey == "" {
+		return fmt.Errorf("Gemini API key is required")
+	}
+
+	request.Profile = strings.TrimSpace(request.Profile)
+	if (request.Profile == "") == (request.StructuredProfile == nil) {
+		return fmt.Errorf("Exactly one of profile or structured_profile is required")
+	}
+	if len(request.Profile) > maxProfileTextLength {
+		return fmt.Errorf("profile must be at most %d characters", maxProfileTextLength)
+	}
+	if profile := request.StructuredProfile; profile != nil && len(profile.Skills) == 0 && len(profile.PotentialJobTitles) == 0 {
+		return fmt.Errorf("structured_profile needs at least one skill or potential job title")
+	}
+
+	if len(request.Jobs) == 0 || len(request.Jobs) > ai.MaxRankingJobs {
+		return fmt.Errorf("jobs must contain between 1 and %d jobs", ai.MaxRankingJobs)
+	}
+	for i, job := range request.Jobs {
+		if strings.TrimSpace(job.Title) == "" || strings.TrimSpace(job.URL) == "" {
+			return fmt.Errorf("jobs[%d] needs a title and a url", i)
+		}
+	}
+
+	return validateRankingOverrides(request.Ranking)
+}
+
+// validateRankingOverrides applies the bounds parseRankingOverrides enforces
+// on form fields to overrides sent as JSON.
+func validateRankingOverrides(overrides dtos.RankingOverrides) error {
+	intFields := []struct {
+		name  string
+		value *int
+		min   int
+		max   int
+	}{
+		{"max_jobs", overrides.MaxJobs, 1, ai.MaxRankingJobs},
+		{"batch_size", overrides.BatchSize, 1, ai.MaxRankingBatchSize},
+		{"max_concurrency", overrides.MaxConcurrency, 1, ai.MaxRankingConcurrency},
+		{"top_n", overrides.TopN, 1, ai.MaxRankingJobs},
+		{"anchor_jobs", overrides.AnchorJobs, 0, ai.MaxAnchorJobs},
+		{"prerank_top_k", overrides.PreRankTopK, 0, ai.MaxRankingJobs},
+	}
+	for _, field := range intFields {
+		if field.value != nil && (*field.value < field.min || *field.value > field.max) {
+			return fmt.Errorf("ranking.%s must be an integer between %d and %d", field.name, field.min, field.max)
+		}
+	}
+
+	if overrides.MinScore != nil && (*overrides.MinScore < 0 || *overrides.MinScore > 100) {
+		return fmt.Errorf("ranking.min_score must be a number between 0 and 100")
+	}
+
+	if weights := overrides.Weights; weights != nil {
+		weightFields := []struct {
+			name  string
+			value *float64
+		}{
+			{"title", weights.Title},
+			{"skills", weights.Skills},
+			{"seniority", weights.Seniority},
+			{"location", weights.Location},
+			{"domain", weights.Domain},
+		}
+		for _, field := range weightFields {
+			if field.value != nil && *field.value < 0 {
+				return fmt.Errorf("ranking.weights.%s must be a non-negative number", field.name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lakshya1goel/job-assistance/config"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/api/service"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+// OwnerTokenHeader carries the token that scopes saved searches and the
+// application board to the caller who created them.
+const OwnerTokenHeader = "X-Owner-Token"
+
+func (c *JobController) CreateSavedSearch(ctx *gin.Context) {
+	input := service.SavedSearchInput{
+		OwnerToken:    strings.TrimSpace(ctx.GetHeader(OwnerTokenHeader)),
+		Name:          strings.TrimSpace(ctx.PostForm("name")),
+		RunID:         ctx.PostForm("run_id"),
+		APIKey:        ctx.PostForm("api_key"),
+		Schedule:      ctx.DefaultPostForm("schedule", "@daily"),
+		AlertMinScore: 60,
+		NotifyEmail:   strings.TrimSpace(ctx.PostForm("notify_email")),
+		WebhookURL:    strings.TrimSpace(ctx.PostForm("webhook_url")),
+		WebhookSecret: ctx.PostForm("webhook_secret"),
+		Enabled:       ctx.DefaultPostForm("enabled", "true") != "false",
+	}
+
+	if input.Name == "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "name is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if input.APIKey == "" {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     "Gemini API key is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	if input.NotifyEmail != "" {
+		if config.GetSMTPConfig().Host == "" {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error:     "Email notifications are not configured on this server",
+				Success:   false,
+				Timestamp: time.Now(),
+			})
+			return
+		}
+		if _, err := mail.ParseAddress(input.NotifyEmail); err != nil {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error:     "notify_email must be a valid email address",
+				Success:   false,
+				Timestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	if input.WebhookURL != "" {
+		parsed, err := url.Parse(input.WebhookURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error:     "webhook_url must be an http or https URL",
+				Success:   false,
+				Timestamp: time.Now(),
+			})
+			return
+		}
+	}
+
+	if value := ctx.PostForm("alert_min_score"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > 100 {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+				Error:     "alert_min_score must be a number between 0 and 100",
+				Success:   false,
+				Timestamp: time.Now(),
+			})
+			return
+		}
+		input.AlertMinScore = parsed
+	}
+
+	if input.RunID == "" {
+		pdfBytes, ok := readResumePDF(ctx)
+		if !ok {
+			return
+		}
+		input.PDFBytes = pdfBytes
+	}
+
+	request, err := parseSearchRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	input.Request = request
+
+	search, err := c.service.CreateSavedSearch(ctx.Request.Context(), input)
+	if errors.Is(err, service.ErrInvalidSchedule) || errors.Is(err, service.ErrInvalidOwnerToken) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if errors.Is(err, repo.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Search run not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, search)
+}
+
+func (c *JobController) ListSavedSearches(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	searches, err := c.service.ListSavedSearches(ctx.Request.Context(), ownerToken)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.SavedSearchListResponse{
+		Searches: searches,
+		Total:    len(searches),
+		Success:  true,
+	})
+}
+
+func (c *JobController) GetSavedSearch(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	search, err := c.service.GetSavedSearch(ctx.Request.Context(), ownerToken, ctx.Param("search_id"))
+	if err != nil {
+		respondSavedSearchError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, search)
+}
+
+func (c *JobController) DeleteSavedSearch(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteSavedSearch(ctx.Request.Context(), ownerToken, ctx.Param("search_id")); err != nil {
+		respondSavedSearchError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c *JobController) RunSavedSearch(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.RunSavedSearch(ctx.Request.Context(), ownerToken, ctx.Param("search_id"))
+	if err != nil {
+		respondSavedSearchError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c *JobController) ListSavedSearchMatches(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	searchID := ctx.Param("search_id")
+	matches, err := c.service.ListSavedSearchMatches(ctx.Request.Context(), ownerToken, searchID)
+	if err != nil {
+		respondSavedSearchError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.SavedSearchMatchesResponse{
+		SearchID: searchID,
+		Matches:  matches,
+		Total:    len(matches),
+		Success:  true,
+	})
+}
+
+func requireOwnerToken(ctx *gin.Context) (string, bool) {
+	ownerToken := strings.TrimSpace(ctx.GetHeader(OwnerTokenHeader))
+	if ownerToken == "" {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorResponse{
+			Error:     OwnerTokenHeader + " header is required",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return "", false
+	}
+	return ownerToken, true
+}
+
+func respondSavedSearchError(ctx *gin.Context, err error) {
+	if errors.Is(err, service.ErrSearchMissingKey) {
+		ctx.JSON(http.StatusConflict, dtos.ErrorResponse{
+			Error:     err.Error(),
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+	if errors.Is(err, repo.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, dtos.ErrorResponse{
+			Error:     "Saved search not found",
+			Success:   false,
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, dtos.ErrorResponse{
+		Error:     err.Error(),
+		Success:   false,
+		Timestamp: time.Now(),
+	})
+}
+
+func (c *JobController) ListNotificationDeliveries(ctx *gin.Context) {
+	ownerToken, ok := requireOwnerToken(ctx)
+	if !ok {
+		return
+	}
+
+	searchID := ctx.Param("search_id")
+	deliveries, err := c.service.ListNotificationDeliveries(ctx.Request.Context(), ownerToken, searchID)
+	if err != nil {
+		respondSavedSearchError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.NotificationDeliveriesResponse{
+		SearchID:   searchID,
+		Deliveries: deliveries,
+		Total:      len(deliveries),
+		Success:    true,
+	})
+}
@@ -0,0 +1,168 @@
+package repo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+type ApplicationRepository interface {
+	CreateApplication(ctx context.Context, application *dtos.Application) error
+	UpdateApplication(ctx context.Context, application *dtos.Application) error
+	GetApplication(ctx context.Context, ownerHash, id string) (*dtos.Application, error)
+	ListApplications(ctx context.Context, ownerHash string) ([]dtos.Application, error)
+	DeleteApplication(ctx context.Context, ownerHash, id string) error
+	FindByJobKeys(ctx context.Context, ownerHash string, keys []string) (map[string]dtos.Application, error)
+	SetProfile(ctx context.Context, id, profile string) error
+	SetInterviewPrep(ctx context.Context, id string, prep *dtos.InterviewPrep) error
+}
+
+// Each owner has their own board: lookups by ID only see the owner's
+// applications and byJobKey is keyed by owner and job.
+type inMemoryApplicationRepository struct {
+	mu           sync.RWMutex
+	applications map[string]dtos.Application
+	byJobKey     map[string]string
+}
+
+func NewApplicationRepository() ApplicationRepository {
+	return &inMemoryApplicationRepository{
+		applications: make(map[string]dtos.Application),
+		byJobKey:     make(map[string]string),
+	}
+}
+
+// CreateApplication returns ErrAlreadyExists when the job is already on the
+// owner's board.
+func (r *inMemoryApplicationRepository) CreateApplication(ctx context.Context, application *dtos.Application) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobKey := ownerJobKey(application.OwnerTokenHash, application.JobKey)
+	if _, exists := r.byJobKey[jobKey]; exists {
+		return ErrAlreadyExists
+	}
+
+	application.ID = newID()
+	now := time.Now()
+	if application.CreatedAt.IsZero() {
+		application.CreatedAt = now
+	}
+	application.UpdatedAt = now
+
+	r.applications[application.ID] = cloneApplication(*application)
+	r.byJobKey[jobKey] = application.ID
+	return nil
+}
+
+func (r *inMemoryApplicationRepository) UpdateApplication(ctx context.Context, application *dtos.Application) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.applications[application.ID]; !ok || stored.OwnerTokenHash != application.OwnerTokenHash {
+		return ErrNotFound
+	}
+	application.UpdatedAt = time.Now()
+	r.applications[application.ID] = cloneApplication(*application)
+	return nil
+}
+
+func (r *inMemoryApplicationRepository) GetApplication(ctx context.Context, ownerHash, id string) (*dtos.Application, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	application, ok := r.applications[id]
+	if !ok || application.OwnerTokenHash != ownerHash {
+		return nil, ErrNotFound
+	}
+	application = cloneApplication(application)
+	return &application, nil
+}
+
+// ListApplications returns the owner's board with the most recently updated
+// applications first.
+func (r *inMemoryApplicationRepository) ListApplications(ctx context.Context, ownerHash string) ([]dtos.Application, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	applications := []dtos.Application{}
+	for _, application := range r.applications {
+		if application.OwnerTokenHash != ownerHash {
+			continue
+		}
+		applications = append(applications, cloneApplication(application))
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return applications[i].UpdatedAt.After(applications[j].UpdatedAt)
+	})
+	return applications, nil
+}
+
+func (r *inMemoryApplicationRepository) DeleteApplication(ctx context.Context, ownerHash, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	application, ok := r.applications[id]
+	if !ok || application.OwnerTokenHash != ownerHash {
+		return ErrNotFound
+	}
+	delete(r.applications, id)
+	delete(r.byJobKey, ownerJobKey(ownerHash, application.JobKey))
+	return nil
+}
+
+func (r *inMemoryApplicationRepository) FindByJobKeys(ctx context.Context, ownerHash string, keys []string) (map[string]dtos.Application, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	found := make(map[string]dtos.Application)
+	for _, key := range keys {
+		if id, ok := r.byJobKey[ownerJobKey(ownerHash, key)]; ok {
+			found[key] = cloneApplication(r.applications[id])
+		}
+	}
+	return found, nil
+}
+
+// SetProfile and SetInterviewPrep update a single field so results of slow
+// LLM calls don't overwrite edits made to the application in the meantime.
+func (r *inMemoryApplicationRepository) SetProfile(ctx context.Context, id, profile string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	application, ok := r.applications[id]
+	if !ok {
+		return ErrNotFound
+	}
+	application.Profile = profile
+	r.applications[id] = application
+	return nil
+}
+
+func (r *inMemoryApplicationRepository) SetInterviewPrep(ctx context.Context, id string, prep *dtos.InterviewPrep) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	application, ok := r.applications[id]
+	if !ok {
+		return ErrNotFound
+	}
+	application.InterviewPrep = prep
+	application.UpdatedAt = time.Now()
+	r.applications[id] = application
+	return nil
+}
+
+func ownerJobKey(ownerHash, jobKey string) string {
+	return ownerHash + "|" + jobKey
+}
+
+// cloneApplication copies the slices so callers cannot modify stored data.
+func cloneApplication(application dtos.Application) dtos.Application {
+	application.Tags = append([]string{}, application.Tags...)
+	application.StatusHistory = append([]dtos.ApplicationStatusChange{}, application.StatusHistory...)
+	return application
+}
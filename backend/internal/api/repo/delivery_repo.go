@@ -0,0 +1,54 @@
+package repo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+type DeliveryRepository interface {
+	SaveDelivery(ctx context.Context, delivery *dtos.NotificationDelivery) error
+	ListDeliveries(ctx context.Context, searchID string) ([]dtos.NotificationDelivery, error)
+}
+
+type inMemoryDeliveryRepository struct {
+	mu           sync.RWMutex
+	deliveries   map[string][]dtos.NotificationDelivery
+	maxPerSearch int
+}
+
+func NewDeliveryRepository(maxPerSearch int) DeliveryRepository {
+	if maxPerSearch <= 0 {
+		maxPerSearch = 100
+	}
+	return &inMemoryDeliveryRepository{
+		deliveries:   make(map[string][]dtos.NotificationDelivery),
+		maxPerSearch: maxPerSearch,
+	}
+}
+
+func (r *inMemoryDeliveryRepository) SaveDelivery(ctx context.Context, delivery *dtos.NotificationDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if delivery.ID == "" {
+		delivery.ID = newID()
+	}
+
+	stored := append(r.deliveries[delivery.SearchID], *delivery)
+	if len(stored) > r.maxPerSearch {
+		stored = stored[len(stored)-r.maxPerSearch:]
+	}
+	r.deliveries[delivery.SearchID] = stored
+	return nil
+}
+
+func (r *inMemoryDeliveryRepository) ListDeliveries(ctx context.Context, searchID string) ([]dtos.NotificationDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deliveries := make([]dtos.NotificationDelivery, len(r.deliveries[searchID]))
+	copy(deliveries, r.deliveries[searchID])
+	return deliveries, nil
+}
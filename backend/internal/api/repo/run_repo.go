@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+type RunRepository interface {
+	SaveRun(ctx context.Context, run *dtos.SearchRun) error
+	GetRun(ctx context.Context, id string) (*dtos.SearchRun, error)
+}
+
+type inMemoryRunRepository struct {
+	mu      sync.RWMutex
+	runs    map[string]*dtos.SearchRun
+	order   []string
+	maxRuns int
+}
+
+func NewRunRepository(maxRuns int) RunRepository {
+	if maxRuns <= 0 {
+		maxRuns = 100
+	}
+	return &inMemoryRunRepository{
+		runs:    make(map[string]*dtos.SearchRun),
+		maxRuns: maxRuns,
+	}
+}
+
+func (r *inMemoryRunRepository) SaveRun(ctx context.Context, run *dtos.SearchRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if run.ID == "" {
+		run.ID = newID()
+	}
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = time.Now()
+	}
+
+	if _, exists := r.runs[run.ID]; !exists {
+		r.order = append(r.order, run.ID)
+	}
+	r.runs[run.ID] = run
+
+	for len(r.order) > r.maxRuns {
+		delete(r.runs, r.order[0])
+		r.order = r.order[1:]
+	}
+
+	return nil
+}
+
+func (r *inMemoryRunRepository) GetRun(ctx context.Context, id string) (*dtos.SearchRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	run, ok := r.runs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return run, nil
+}
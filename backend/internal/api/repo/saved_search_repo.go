@@ -0,0 +1,154 @@
+package repo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+const maxMatchesPerSearch = 500
+
+type SavedSearchRepository interface {
+	CreateSearch(ctx context.Context, search *dtos.SavedSearch) error
+	UpdateSearch(ctx context.Context, search *dtos.SavedSearch) error
+	GetSearch(ctx context.Context, id string) (*dtos.SavedSearch, error)
+	ListSearches(ctx context.Context) ([]dtos.SavedSearch, error)
+	DeleteSearch(ctx context.Context, id string) error
+	MarkSeen(ctx context.Context, searchID string, keys []string) ([]bool, error)
+	AddMatches(ctx context.Context, searchID string, matches []dtos.SavedSearchMatch) error
+	ListMatches(ctx context.Context, searchID string) ([]dtos.SavedSearchMatch, error)
+}
+
+type inMemorySavedSearchRepository struct {
+	mu       sync.RWMutex
+	searches map[string]dtos.SavedSearch
+	seen     map[string]map[string]bool
+	matches  map[string][]dtos.SavedSearchMatch
+}
+
+func NewSavedSearchRepository() SavedSearchRepository {
+	return &inMemorySavedSearchRepository{
+		searches: make(map[string]dtos.SavedSearch),
+		seen:     make(map[string]map[string]bool),
+		matches:  make(map[string][]dtos.SavedSearchMatch),
+	}
+}
+
+func (r *inMemorySavedSearchRepository) CreateSearch(ctx context.Context, search *dtos.SavedSearch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	search.ID = newID()
+	if search.CreatedAt.IsZero() {
+		search.CreatedAt = time.Now()
+	}
+	r.searches[search.ID] = *search
+	return nil
+}
+
+func (r *inMemorySavedSearchRepository) UpdateSearch(ctx context.Context, search *dtos.SavedSearch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.searches[search.ID]; !ok {
+		return ErrNotFound
+	}
+	r.searches[search.ID] = *search
+	return nil
+}
+
+func (r *inMemorySavedSearchRepository) GetSearch(ctx context.Context, id string) (*dtos.SavedSearch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	search, ok := r.searches[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &search, nil
+}
+
+func (r *inMemorySavedSearchRepository) ListSearches(ctx context.Context) ([]dtos.SavedSearch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	searches := make([]dtos.SavedSearch, 0, len(r.searches))
+	for _, search := range r.searches {
+		searches = append(searches, search)
+	}
+	sort.Slice(searches, func(i, j int) bool {
+		return searches[i].CreatedAt.Before(searches[j].CreatedAt)
+	})
+	return searches, nil
+}
+
+func (r *inMemorySavedSearchRepository) DeleteSearch(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.searches[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.searches, id)
+	delete(r.seen, id)
+	delete(r.matches, id)
+	return nil
+}
+
+// MarkSeen records the given job keys for a saved search and reports, per
+// key, whether it had not been seen before.
+func (r *inMemorySavedSearchRepository) MarkSeen(ctx context.Context, searchID string, keys []string) ([]bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.searches[searchID]; !ok {
+		return nil, ErrNotFound
+	}
+
+	seen, ok := r.seen[searchID]
+	if !ok {
+		seen = make(map[string]bool)
+		r.seen[searchID] = seen
+	}
+
+	isNew := make([]bool, len(keys))
+	for i, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			isNew[i] = true
+		}
+	}
+	return isNew, nil
+}
+
+func (r *inMemorySavedSearchRepository) AddMatches(ctx context.Context, searchID string, matches []dtos.SavedSearchMatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.searches[searchID]; !ok {
+		return ErrNotFound
+	}
+
+	stored := append(r.matches[searchID], matches...)
+	if len(stored) > maxMatchesPerSearch {
+		stored = stored[len(stored)-maxMatchesPerSearch:]
+	}
+	r.matches[searchID] = stored
+	return nil
+}
+
+func (r *inMemorySavedSearchRepository) ListMatches(ctx context.Context, searchID string) ([]dtos.SavedSearchMatch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.searches[searchID]; !ok {
+		return nil, ErrNotFound
+	}
+
+	matches := make([]dtos.SavedSearchMatch, len(r.matches[searchID]))
+	copy(matches, r.matches[searchID])
+	return matches, nil
+}
@@ -9,5 +9,31 @@ func JobRoutes(router *gin.RouterGroup, jobController *controller.JobController)
 	jobRouter := router.Group("/job")
 	{
 		jobRouter.POST("/", jobController.FetchStructuredJobs)
+		jobRouter.GET("/runs/:run_id/skill-gaps", jobController.GetSkillGaps)
+		jobRouter.GET("/runs/:run_id/export", jobController.ExportRun)
+		jobRouter.POST("/ats-report", jobController.ATSReport)
+		jobRouter.POST("/score", jobController.ScoreJob)
+
+		jobRouter.POST("/searches", jobController.CreateSavedSearch)
+		jobRouter.GET("/searches", jobController.ListSavedSearches)
+		jobRouter.GET("/searches/:search_id", jobController.GetSavedSearch)
+		jobRouter.DELETE("/searches/:search_id", jobController.DeleteSavedSearch)
+		jobRouter.POST("/searches/:search_id/run", jobController.RunSavedSearch)
+		jobRouter.GET("/searches/:search_id/matches", jobController.ListSavedSearchMatches)
+		jobRouter.GET("/searches/:search_id/deliveries", jobController.ListNotificationDeliveries)
+
+		jobRouter.POST("/applications", jobController.CreateApplication)
+		jobRouter.GET("/applications", jobController.ListApplications)
+		jobRouter.GET("/applications/export", jobController.ExportApplications)
+		jobRouter.GET("/applications/:application_id", jobController.GetApplication)
+		jobRouter.PATCH("/applications/:application_id", jobController.UpdateApplication)
+		jobRouter.DELETE("/applications/:application_id", jobController.DeleteApplication)
+		jobRouter.POST("/:application_id/cover-letter", jobController.GenerateCoverLetter)
+		jobRouter.POST("/:application_id/resume-tailoring", jobController.TailorResume)
+		jobRouter.POST("/:application_id/interview-prep", jobController.GenerateInterviewPrep)
 	}
 }
+
+func RankRoutes(router *gin.RouterGroup, jobController *controller.JobController) {
+	router.POST("/rank", jobController.RankJobs)
+}
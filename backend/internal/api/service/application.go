@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+)
+
+const (
+	ApplicationStatusSaved        = "saved"
+	ApplicationStatusApplied      = "applied"
+	ApplicationStatusInterviewing = "interviewing"
+	ApplicationStatusOffer        = "offer"
+	ApplicationStatusRejected     = "rejected"
+)
+
+var ErrJobNotInRun = errors.New("job not found in search run")
+
+var ApplicationStatuses = []string{
+	ApplicationStatusSaved,
+	ApplicationStatusApplied,
+	ApplicationStatusInterviewing,
+	ApplicationStatusOffer,
+	ApplicationStatusRejected,
+}
+
+func ValidApplicationStatus(status string) bool {
+	return slices.Contains(ApplicationStatuses, status)
+}
+
+func (s *jobService) CreateApplication(ctx context.Context, ownerToken string, request dtos.CreateApplicationRequest) (*dtos.Application, error) {
+	ownerHash, err := boardOwner(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// The run's profile is kept on the application so cover letters and other
+	// job-specific documents still work after the run has been evicted.
+	var run *dtos.SearchRun
+	if request.RunID != "" {
+		if run, err = s.runs.GetRun(ctx, request.RunID); err != nil {
+			return nil, err
+		}
+	}
+
+	var rankedJob dtos.RankedJob
+	if request.Job != nil {
+		rankedJob = *request.Job
+	} else {
+		found := false
+		wantKey := ai.JobURLKey(dtos.Job{URL: request.JobURL})
+		for _, candidate := range run.Jobs {
+			if ai.JobURLKey(candidate.Job) == wantKey {
+				rankedJob, found = candidate, true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrJobNotInRun
+		}
+	}
+	rankedJob.ApplicationID = ""
+	rankedJob.ApplicationStatus = ""
+
+	var profile string
+	if run != nil {
+		profile = run.Profile
+	}
+
+	status := request.Status
+	if status == "" {
+		status = ApplicationStatusSaved
+	}
+
+	now := time.Now()
+	application := &dtos.Application{
+		OwnerTokenHash: ownerHash,
+		JobKey:         ai.JobURLKey(rankedJob.Job),
+		Job:            rankedJob,
+		Profile:        profile,
+		RunID:          request.RunID,
+		Status:         status,
+		Notes:          request.Notes,
+		Tags:           normalizeTags(request.Tags),
+		AppliedAt:      request.AppliedAt,
+		FollowUpAt:     request.FollowUpAt,
+		StatusHistory:  []dtos.ApplicationStatusChange{{Status: status, ChangedAt: now}},
+		CreatedAt:      now,
+	}
+	if application.AppliedAt == nil && status != ApplicationStatusSaved {
+		application.AppliedAt = &now
+	}
+
+	if err := s.applications.CreateApplication(ctx, application); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+// ListApplications returns the owner's board, optionally narrowed to one
+// status and/or tag.
+func (s *jobService) ListApplications(ctx context.Context, ownerToken, status, tag string) ([]dtos.Application, error) {
+	ownerHash, err := boardOwner(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := s.applications.ListApplications(ctx, ownerHash)
+	if err != nil {
+		return nil, err
+	}
+
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	filtered := []dtos.Application{}
+	for _, application := range applications {
+		if status != "" && application.Status != status {
+			continue
+		}
+		if tag != "" && !slices.Contains(application.Tags, tag) {
+			continue
+		}
+		filtered = append(filtered, application)
+	}
+	return filtered, nil
+}
+
+func (s *jobService) GetApplication(ctx context.Context, ownerToken, id string) (*dtos.Application, error) {
+	ownerHash, err := boardOwner(ownerToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.applications.GetApplication(ctx, ownerHash, id)
+}
+
+func (s *jobService) UpdateApplication(ctx context.Context, ownerToken, id string, request dtos.UpdateApplicationRequest) (*dtos.Application, error) {
+	application, err := s.GetApplication(ctx, ownerToken, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if request.Status != nil && *request.Status != application.Status {
+		application.Status = *request.Status
+		application.StatusHistory = append(application.StatusHistory, dtos.ApplicationStatusChange{
+			Status:    application.Status,
+			ChangedAt: now,
+		})
+		if application.AppliedAt == nil && application.Status != ApplicationStatusSaved {
+			application.AppliedAt = &now
+		}
+	}
+	if request.Notes != nil {
+		application.Notes = *request.Notes
+	}
+	if request.Tags != nil {
+		application.Tags = normalizeTags(*request.Tags)
+	}
+	if request.AppliedAt != nil {
+		application.AppliedAt = request.AppliedAt
+	}
+	if request.FollowUpAt != nil {
+		application.FollowUpAt = request.FollowUpAt
+	}
+
+	if err := s.applications.UpdateApplication(ctx, application); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+// ProfileSource says where to take the candidate profile from when an
+// application was saved without one: a stored search run or a resume PDF.
+type ProfileSource struct {
+	RunID    string
+	PDFBytes []byte
+}
+
+var ErrProfileRequired = errors.New("no candidate profile is stored for this application; provide run_id or a resume")
+
+// applicationProfile returns the candidate profile for the application,
+// falling back to the given source and remembering the result on the
+// application.
+func (s *jobService) applicationProfile(ctx context.Context, application *dtos.Application, source ProfileSource, apiKey string) (string, error) {
+	if application.Profile != "" && source.RunID == "" && len(source.PDFBytes) == 0 {
+		return application.Profile, nil
+	}
+
+	profile, err := s.profileFromSource(ctx, source, apiKey)
+	if err != nil {
+		return "", err
+	}
+
+	application.Profile = profile
+	if err := s.applications.SetProfile(ctx, application.ID, profile); err != nil {
+		logger.FromContext(ctx).Warn("failed to store application profile", "application_id", application.ID, "error", err)
+	}
+	return profile, nil
+}
+
+func (s *jobService) profileFromSource(ctx context.Context, source ProfileSource, apiKey string) (string, error) {
+	switch {
+	case source.RunID != "":
+		run, err := s.runs.GetRun(ctx, source.RunID)
+		if err != nil {
+			return "", err
+		}
+		return run.Profile, nil
+	case len(source.PDFBytes) > 0:
+		return s.extractProfile(ctx, source.PDFBytes, dtos.LocationPreference{}, apiKey)
+	default:
+		return "", ErrProfileRequired
+	}
+}
+
+func (s *jobService) DeleteApplication(ctx context.Context, ownerToken, id string) error {
+	ownerHash, err := boardOwner(ownerToken)
+	if err != nil {
+		return err
+	}
+	return s.applications.DeleteApplication(ctx, ownerHash, id)
+}
+
+// boardOwner returns the hash that scopes the application board to the
+// caller's owner token.
+func boardOwner(ownerToken string) (string, error) {
+	if len(ownerToken) < minOwnerTokenLength {
+		return "", ErrInvalidOwnerToken
+	}
+	return hashOwnerToken(ownerToken), nil
+}
+
+// trackingOwner is boardOwner for endpoints where the token is optional; it
+// returns "" when no usable token was sent.
+func trackingOwner(ownerToken string) string {
+	ownerHash, err := boardOwner(ownerToken)
+	if err != nil {
+		return ""
+	}
+	return ownerHash
+}
+
+// markTrackedJobs flags ranked jobs that are already on the owner's
+// application board so clients can show their status next to the search
+// result. Nothing is marked without an owner.
+func (s *jobService) markTrackedJobs(ctx context.Context, ownerHash string, rankedJobs []dtos.RankedJob) {
+	if ownerHash == "" || len(rankedJobs) == 0 {
+		return
+	}
+
+	keys := make([]string, len(rankedJobs))
+	for i, rankedJob := range rankedJobs {
+		keys[i] = ai.JobURLKey(rankedJob.Job)
+	}
+
+	tracked, err := s.applications.FindByJobKeys(ctx, ownerHash, keys)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to look up tracked applications", "error", err)
+		return
+	}
+
+	for i, key := range keys {
+		if application, ok := tracked[key]; ok {
+			rankedJobs[i].ApplicationID = application.ID
+			rankedJobs[i].ApplicationStatus = application.Status
+		}
+	}
+}
+
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
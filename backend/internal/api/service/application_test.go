@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestApplicationBoardIsScopedToOwner(t *testing.T) {
+	ctx := context.Background()
+	s := NewJobService(repo.NewRunRepository(10), repo.NewSavedSearchRepository(), repo.NewDeliveryRepository(10), repo.NewApplicationRepository(), nil).(*jobService)
+
+	alice, bob := "alice-owner-token-0001", "bob-owner-token-000002"
+	job := &dtos.RankedJob{Job: dtos.Job{Title: "Go Developer", URL: "https://jobs.example/1"}}
+
+	created, err := s.CreateApplication(ctx, alice, dtos.CreateApplicationRequest{Job: job})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateApplication(ctx, alice, dtos.CreateApplicationRequest{Job: job}); !errors.Is(err, repo.ErrAlreadyExists) {
+		t.Errorf("saving the same job twice: err = %v, want ErrAlreadyExists", err)
+	}
+	// Another owner can save the same job to their own board.
+	if _, err := s.CreateApplication(ctx, bob, dtos.CreateApplicationRequest{Job: job}); err != nil {
+		t.Errorf("saving a job another owner saved: err = %v", err)
+	}
+
+	if _, err := s.GetApplication(ctx, bob, created.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("GetApplication with another token: err = %v, want ErrNotFound", err)
+	}
+	status := ApplicationStatusApplied
+	if _, err := s.UpdateApplication(ctx, bob, created.ID, dtos.UpdateApplicationRequest{Status: &status}); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("UpdateApplication with another token: err = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteApplication(ctx, bob, created.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("DeleteApplication with another token: err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.ATSReports(ctx, ATSInput{ResumeText: "Go", OwnerToken: bob, ApplicationID: created.ID}); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("ATSReports with another token: err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.ListApplications(ctx, "short", "", ""); !errors.Is(err, ErrInvalidOwnerToken) {
+		t.Errorf("ListApplications with a short token: err = %v, want ErrInvalidOwnerToken", err)
+	}
+
+	mine, err := s.ListApplications(ctx, alice, "", "")
+	if err != nil || len(mine) != 1 || mine[0].ID != created.ID {
+		t.Fatalf("ListApplications = %v, %v; want only the owner's application", mine, err)
+	}
+
+	tests := []struct {
+		name   string
+		owner  string
+		wantID string
+	}{
+		{"owner", hashOwnerToken(alice), created.ID},
+		{"no owner", "", ""},
+		{"unknown owner", hashOwnerToken("carol-owner-token-0003"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankedJobs := []dtos.RankedJob{{Job: job.Job}}
+			s.markTrackedJobs(ctx, tt.owner, rankedJobs)
+			if rankedJobs[0].ApplicationID != tt.wantID {
+				t.Errorf("ApplicationID = %q, want %q", rankedJobs[0].ApplicationID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/analysis"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/resume"
+)
+
+var ErrResumeUnreadable = errors.New("could not read text from the resume PDF; paste it as resume_text instead")
+
+// ATSInput selects the resume and the jobs for an ATS keyword report: a
+// single application, or a search run optionally narrowed to one job URL.
+// OwnerToken is required when reading an application.
+type ATSInput struct {
+	ResumePDF     []byte
+	ResumeText    string
+	OwnerToken    string
+	ApplicationID string
+	RunID         string
+	JobURL        string
+}
+
+// ATSReports runs the keyword analyzer locally; it needs no Gemini key.
+func (s *jobService) ATSReports(ctx context.Context, input ATSInput) ([]dtos.ATSJobReport, error) {
+	resumeText := strings.TrimSpace(input.ResumeText)
+	if resumeText == "" {
+		text, err := resume.ExtractText(input.ResumePDF)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrResumeUnreadable, err)
+		}
+		resumeText = text
+	}
+
+	var rankedJobs []dtos.RankedJob
+	if input.ApplicationID != "" {
+		application, err := s.GetApplication(ctx, input.OwnerToken, input.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+		rankedJobs = []dtos.RankedJob{application.Job}
+	} else {
+		run, err := s.runs.GetRun(ctx, input.RunID)
+		if err != nil {
+			return nil, err
+		}
+		rankedJobs = run.Jobs
+		if input.JobURL != "" {
+			rankedJobs = nil
+			wantKey := ai.JobURLKey(dtos.Job{URL: input.JobURL})
+			for _, rankedJob := range run.Jobs {
+				if ai.JobURLKey(rankedJob.Job) == wantKey {
+					rankedJobs = append(rankedJobs, rankedJob)
+				}
+			}
+			if len(rankedJobs) == 0 {
+				return nil, ErrJobNotInRun
+			}
+		}
+	}
+
+	now := time.Now()
+	reports := make([]dtos.ATSJobReport, len(rankedJobs))
+	for i, rankedJob := range rankedJobs {
+		reports[i] = dtos.ATSJobReport{
+			Job: rankedJob,
+			ATS: analysis.ATSMatch(resumeText, rankedJob.Job, now),
+		}
+	}
+	return reports, nil
+}
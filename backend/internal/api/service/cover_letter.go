@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+)
+
+func (s *jobService) GenerateCoverLetter(ctx context.Context, ownerToken, applicationID string, source ProfileSource, options dtos.CoverLetterOptions, apiKey string) (*dtos.CoverLetterResponse, error) {
+	tracker := usage.NewTracker()
+	ctx = usage.WithTracker(ctx, tracker)
+
+	application, err := s.GetApplication(ctx, ownerToken, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.applicationProfile(ctx, application, source, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	coverLetterClient := ai.NewCoverLetterClient(ctx, apiKey)
+
+	stageStart := time.Now()
+	stageCtx, span := tracing.Start(ctx, "stage."+metrics.StageCoverLetter)
+	letter, err := coverLetterClient.GenerateCoverLetter(stageCtx, profile, application.Job, options)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageCoverLetter, stageStart, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate cover letter: %w", err)
+	}
+
+	return &dtos.CoverLetterResponse{
+		ApplicationID: application.ID,
+		Tone:          options.Tone,
+		Length:        options.Length,
+		CoverLetter:   letter,
+		WordCount:     len(strings.Fields(letter)),
+		Usage:         tracker.Summary(s.modelPrices),
+		Success:       true,
+	}, nil
+}
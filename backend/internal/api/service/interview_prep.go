@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/analysis"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+)
+
+// GenerateInterviewPrep builds an interview preparation pack for a job on the
+// board and stores it on the application, replacing any earlier one.
+func (s *jobService) GenerateInterviewPrep(ctx context.Context, ownerToken, applicationID string, source ProfileSource, apiKey string) (*dtos.InterviewPrepResponse, error) {
+	tracker := usage.NewTracker()
+	ctx = usage.WithTracker(ctx, tracker)
+
+	application, err := s.GetApplication(ctx, ownerToken, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.applicationProfile(ctx, application, source, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	skillGaps := analysis.KeywordsToAdd(application.Job, profile)
+	interviewPrepClient := ai.NewInterviewPrepClient(ctx, apiKey)
+
+	stageStart := time.Now()
+	stageCtx, span := tracing.Start(ctx, "stage."+metrics.StageInterviewPrep)
+	prep, err := interviewPrepClient.GenerateInterviewPrep(stageCtx, profile, application.Job, skillGaps)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageInterviewPrep, stageStart, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate interview prep: %w", err)
+	}
+
+	prep.GeneratedAt = time.Now()
+	if err := s.applications.SetInterviewPrep(ctx, application.ID, prep); err != nil {
+		return nil, fmt.Errorf("failed to save interview prep: %w", err)
+	}
+
+	return &dtos.InterviewPrepResponse{
+		ApplicationID: application.ID,
+		InterviewPrep: *prep,
+		Usage:         tracker.Summary(s.modelPrices),
+		Success:       true,
+	}, nil
+}
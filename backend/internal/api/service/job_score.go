@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/jobpage"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+)
+
+var (
+	ErrJobPageUnavailable = errors.New("failed to fetch job page")
+	ErrNoJobPosting       = errors.New("no job posting found")
+)
+
+// JobScoreInput is a single job to score, given either as a posting URL or
+// as pasted posting text.
+type JobScoreInput struct {
+	URL        string
+	Text       string
+	Profile    ProfileSource
+	APIKey     string
+	OwnerToken string
+}
+
+// ScoreJob extracts the posting and ranks it against the candidate profile
+// the same way jobs found by a search are ranked.
+func (s *jobService) ScoreJob(ctx context.Context, input JobScoreInput) (*dtos.JobScoreResponse, error) {
+	tracker := usage.NewTracker()
+	ctx = usage.WithTracker(ctx, tracker)
+
+	profile, err := s.profileFromSource(ctx, input.Profile, input.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	stageStart := time.Now()
+	stageCtx, span := tracing.Start(ctx, "stage."+metrics.StageJobExtraction)
+	job, method, err := s.extractJob(stageCtx, input)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageJobExtraction, stageStart, err)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("extracted job posting", "method", method, "title", job.Title)
+
+	options := ai.NormalizeRankingOptions(s.rankingDefaults)
+	rankingClient := s.rankingClient(ctx, input.APIKey)
+
+	stageStart = time.Now()
+	stageCtx, span = tracing.Start(ctx, "stage."+metrics.StageRanking)
+	rankedJob, err := rankingClient.RankJob(stageCtx, profile, *job, options.Weights)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageRanking, stageStart, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank job: %w", err)
+	}
+
+	rankedJobs := []dtos.RankedJob{*rankedJob}
+	s.markTrackedJobs(ctx, trackingOwner(input.OwnerToken), rankedJobs)
+
+	return &dtos.JobScoreResponse{
+		Job:              rankedJobs[0],
+		ExtractionMethod: method,
+		Usage:            tracker.Summary(s.modelPrices),
+		Success:          true,
+	}, nil
+}
+
+// extractJob prefers the page's JSON-LD, then HTML heuristics, and only asks
+// the model when neither found a usable posting.
+func (s *jobService) extractJob(ctx context.Context, input JobScoreInput) (*dtos.Job, string, error) {
+	log := logger.FromContext(ctx)
+
+	var job *dtos.Job
+	var method, pageURL, pageTitle string
+	complete := false
+	text := input.Text
+	source := "manual"
+
+	if input.URL != "" {
+		source = "web"
+		page, err := s.pages.Fetch(ctx, input.URL)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %w", ErrJobPageUnavailable, err)
+		}
+
+		extraction, err := jobpage.Extract(page.URL, page.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse job page: %w", err)
+		}
+		pageURL = page.URL
+		pageTitle = extraction.Job.Title
+		text = extraction.Text
+		if extraction.Job.Title != "" && extraction.Job.Description != "" {
+			job, method = &extraction.Job, extraction.Method
+			complete = extraction.Complete
+		}
+	}
+
+	if !complete {
+		if strings.TrimSpace(text) == "" {
+			return nil, "", ErrNoJobPosting
+		}
+		extracted, err := ai.NewJobPostingClient(ctx, input.APIKey).ExtractJobPosting(ctx, text)
+		switch {
+		case err == nil:
+			job, method = extracted, jobpage.MethodLLM
+		case job == nil:
+			log.Warn("job posting extraction failed, using raw text", "error", err)
+			job, method = jobFromText(text), jobpage.MethodText
+			if pageTitle != "" {
+				job.Title = pageTitle
+			}
+		default:
+			log.Warn("job posting extraction failed, using HTML heuristics", "error", err)
+		}
+	}
+
+	job.URL = pageURL
+	job.Source = source
+	if len(job.RequiredSkills) == 0 {
+		job.RequiredSkills = skills.Default().Extract(job.Description)
+	} else {
+		job.RequiredSkills = skills.Default().Normalize(job.RequiredSkills)
+	}
+	return job, method, nil
+}
+
+// jobFromText is the last resort for pasted text: the first line is taken as
+// the title and the whole text as the description.
+func jobFromText(text string) *dtos.Job {
+	text = strings.TrimSpace(text)
+	title, _, _ := strings.Cut(text, "\n")
+	return &dtos.Job{
+		Title:       strings.TrimSpace(truncateRunes(title, 120)),
+		Description: text,
+	}
+}
+
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit])
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/jobpage"
+)
+
+// A JSON-LD posting without a description used to leave the job nil when
+// the model fallback failed.
+func TestExtractJobTitleOnlyJSONLD(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><script type="application/ld+json">{"@type":"JobPosting","title":"Backend Engineer"}</script></head>
+<body><main><p>Acme is hiring a backend engineer to build Go services on Kubernetes.</p></main></body></html>`)
+	}))
+	defer server.Close()
+
+	// Without a key the model client cannot be created, so extraction has
+	// to fall back to the page itself.
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+
+	s := &jobService{pages: jobpage.NewFetcher(jobpage.Options{AllowPrivate: true})}
+	job, method, err := s.extractJob(context.Background(), JobScoreInput{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Title != "Backend Engineer" || job.URL == "" {
+		t.Errorf("job = %+v (method %q)", job, method)
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+)
+
+// RankJobs runs the ranking stage on its own for jobs the caller found
+// elsewhere. The result is stored as a search run so exports, skill gaps and
+// the application board work with it like with any search.
+func (s *jobService) RankJobs(ctx context.Context, ownerToken string, request dtos.RankJobsRequest) (*dtos.SearchRun, *dtos.UsageSummary, error) {
+	tracker := usage.NewTracker()
+	ctx = usage.WithTracker(ctx, tracker)
+
+	profile := request.Profile
+	if request.StructuredProfile != nil {
+		profile = structuredProfileText(*request.StructuredProfile)
+	}
+
+	// Unlike a search, every supplied job is ranked unless the caller caps it,
+	// so embedding pre-ranking is off unless asked for.
+	base := s.rankingDefaults
+	base.MaxJobs = len(request.Jobs)
+	base.PreRankTopK = 0
+	rankingOptions := ai.NormalizeRankingOptions(ai.MergeRankingOptions(base, request.Ranking))
+
+	jobs := make([]dtos.Job, len(request.Jobs))
+	for i, job := range request.Jobs {
+		if len(job.RequiredSkills) == 0 {
+			job.RequiredSkills = skills.Default().Extract(job.Description)
+		}
+		if job.Source == "" {
+			job.Source = "api"
+		}
+		jobs[i] = job
+	}
+
+	rankingClient := s.rankingClient(ctx, request.APIKey)
+
+	start := time.Now()
+	stageCtx, span := tracing.Start(ctx, "stage."+metrics.StageRanking)
+	rankedJobs, err := rankingClient.RerankJobs(stageCtx, profile, jobs, rankingOptions)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageRanking, start, err)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to rank jobs: %w", err)
+	}
+
+	s.markTrackedJobs(ctx, trackingOwner(ownerToken), rankedJobs)
+
+	run := &dtos.SearchRun{
+		Profile: profile,
+		Jobs:    rankedJobs,
+	}
+	if err := s.runs.SaveRun(ctx, run); err != nil {
+		return nil, nil, fmt.Errorf("failed to save search run: %w", err)
+	}
+
+	return run, tracker.Summary(s.modelPrices), nil
+}
+
+// structuredProfileText renders a structured profile in the same shape as
+// the summaries the profile extraction stage produces.
+func structuredProfileText(profile dtos.ResumeProfile) string {
+	var b strings.Builder
+	if profile.Seniority != "" {
+		fmt.Fprintf(&b, "Professional Level: %s\n", profile.Seniority)
+	}
+	if len(profile.PotentialJobTitles) > 0 {
+		fmt.Fprintf(&b, "Target Job Titles: %s\n", strings.Join(profile.PotentialJobTitles, ", "))
+	}
+	if len(profile.Skills) > 0 {
+		fmt.Fprintf(&b, "Primary Skills: %s\n", strings.Join(skills.Default().Normalize(profile.Skills), ", "))
+	}
+	if profile.BasedIn != nil && *profile.BasedIn != "" {
+		fmt.Fprintf(&b, "Based In: %s\n", *profile.BasedIn)
+	}
+	if profile.WorkLocation != nil && *profile.WorkLocation != "" {
+		fmt.Fprintf(&b, "Preferred Work Location: %s\n", *profile.WorkLocation)
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"google.golang.org/genai"
+)
+
+var jobCountPattern = regexp.MustCompile(`JSON cards of (\d+) jobs`)
+
+// fakeGemini answers every ranking prompt with a 70% match for each job in
+// the batch.
+func fakeGemini(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		match := jobCountPattern.FindSubmatch(body)
+		if match == nil {
+			http.Error(w, "unexpected prompt", http.StatusBadRequest)
+			return
+		}
+		count, _ := strconv.Atoi(string(match[1]))
+
+		evaluations := make([]string, count)
+		for i := range evaluations {
+			evaluations[i] = fmt.Sprintf(`{"job_index": %d, "match_score": 70, "reasons": "fit"}`, i)
+		}
+		text := `{"evaluations": [` + strings.Join(evaluations, ",") + `]}`
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"candidates": []any{map[string]any{
+				"content": map[string]any{"role": "model", "parts": []any{map[string]any{"text": text}}},
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRankJobsRanksEverySuppliedJob(t *testing.T) {
+	ctx := context.Background()
+	server := fakeGemini(t)
+
+	s := NewJobService(repo.NewRunRepository(10), repo.NewSavedSearchRepository(), repo.NewDeliveryRepository(10), repo.NewApplicationRepository(), nil).(*jobService)
+	s.newRankingClient = func(ctx context.Context, apiKey string) *ai.RankingClient {
+		client, err := genai.NewClient(ctx, &genai.ClientConfig{
+			APIKey:      apiKey,
+			Backend:     genai.BackendGeminiAPI,
+			HTTPOptions: genai.HTTPOptions{BaseURL: server.URL + "/"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &ai.RankingClient{Client: client}
+	}
+
+	const jobCount = 100
+	jobs := make([]dtos.Job, jobCount)
+	for i := range jobs {
+		jobs[i] = dtos.Job{Title: fmt.Sprintf("Go Developer %d", i), URL: fmt.Sprintf("https://jobs.example/%d", i)}
+	}
+
+	run, _, err := s.RankJobs(ctx, "", dtos.RankJobsRequest{Profile: "Go developer", Jobs: jobs, APIKey: "test-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(run.Jobs) != jobCount {
+		t.Fatalf("ranked %d jobs, want all %d", len(run.Jobs), jobCount)
+	}
+
+	topK := 5
+	run, _, err = s.RankJobs(ctx, "", dtos.RankJobsRequest{
+		Profile: "Go developer",
+		Jobs:    jobs,
+		APIKey:  "test-key",
+		Ranking: dtos.RankingOverrides{PreRankTopK: &topK},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(run.Jobs) != topK {
+		t.Errorf("ranked %d jobs with prerank_top_k=%d, want %d", len(run.Jobs), topK, topK)
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/analysis"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/resume"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+)
+
+// TailorResume suggests resume edits for a job on the board. An uploaded
+// resume is sent to the model directly so rewrites can quote real bullets;
+// without one, the stored profile is used.
+func (s *jobService) TailorResume(ctx context.Context, ownerToken, applicationID string, source ProfileSource, apiKey string) (*dtos.ResumeTailoringResponse, error) {
+	tracker := usage.NewTracker()
+	ctx = usage.WithTracker(ctx, tracker)
+
+	application, err := s.GetApplication(ctx, ownerToken, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := application.Profile
+	if source.RunID != "" || len(source.PDFBytes) == 0 {
+		profile, err = s.applicationProfile(ctx, application, ProfileSource{RunID: source.RunID}, apiKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check the keywords against the uploaded resume itself, since the stored
+	// profile may be empty or only a summary of it.
+	resumeText := profile
+	if len(source.PDFBytes) > 0 {
+		if text, err := resume.ExtractText(source.PDFBytes); err != nil {
+			logger.FromContext(ctx).Warn("failed to read resume text for keyword check", "application_id", application.ID, "error", err)
+		} else {
+			resumeText = strings.TrimSpace(text + "\n" + profile)
+		}
+	}
+
+	keywords := analysis.KeywordsToAdd(application.Job, resumeText)
+	profileClient := ai.NewProfileClient(ctx, apiKey)
+
+	stageStart := time.Now()
+	stageCtx, span := tracing.Start(ctx, "stage."+metrics.StageResumeTailoring)
+	tailoring, err := profileClient.TailorResume(stageCtx, source.PDFBytes, profile, application.Job, keywords)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageResumeTailoring, stageStart, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tailor resume: %w", err)
+	}
+
+	return &dtos.ResumeTailoringResponse{
+		ApplicationID:   application.ID,
+		ResumeTailoring: *tailoring,
+		Usage:           tracker.Summary(s.modelPrices),
+		Success:         true,
+	}, nil
+}
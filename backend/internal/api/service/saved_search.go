@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/notify"
+	"github.com/lakshya1goel/job-assistance/internal/scheduler"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
+)
+
+const (
+	minOwnerTokenLength   = 16
+	ownerTokenRandomBytes = 24
+)
+
+var (
+	ErrInvalidSchedule   = errors.New("invalid schedule")
+	ErrInvalidOwnerToken = fmt.Errorf("owner token must be at least %d characters", minOwnerTokenLength)
+	ErrSearchMissingKey  = errors.New("saved search has no Gemini API key")
+)
+
+// SavedSearchInput describes a new saved search. The candidate profile comes
+// either from a resume PDF or from a previous search run. OwnerToken scopes
+// the search to its creator; one is generated when it is empty.
+type SavedSearchInput struct {
+	OwnerToken    string
+	Name          string
+	PDFBytes      []byte
+	RunID         string
+	APIKey        string
+	Request       dtos.JobSearchRequest
+	Schedule      string
+	AlertMinScore float64
+	NotifyEmail   string
+	WebhookURL    string
+	WebhookSecret string
+	Enabled       bool
+}
+
+func (s *jobService) CreateSavedSearch(ctx context.Context, input SavedSearchInput) (*dtos.CreateSavedSearchResponse, error) {
+	schedule, err := scheduler.ParseSchedule(input.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+
+	ownerToken, generated := input.OwnerToken, false
+	if ownerToken == "" {
+		if ownerToken, err = newOwnerToken(); err != nil {
+			return nil, err
+		}
+		generated = true
+	} else if len(ownerToken) < minOwnerTokenLength {
+		return nil, ErrInvalidOwnerToken
+	}
+
+	var profile string
+	locationPreference := input.Request.LocationPreference
+	if input.RunID != "" {
+		run, err := s.runs.GetRun(ctx, input.RunID)
+		if err != nil {
+			return nil, err
+		}
+		profile = run.Profile
+		locationPreference = run.LocationPreference
+	} else {
+		profile, err = s.extractProfile(ctx, input.PDFBytes, locationPreference, input.APIKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	search := &dtos.SavedSearch{
+		Name:               input.Name,
+		Profile:            profile,
+		APIKey:             input.APIKey,
+		OwnerTokenHash:     hashOwnerToken(ownerToken),
+		LocationPreference: locationPreference,
+		Filters:            input.Request.Filters,
+		Ranking:            input.Request.Ranking,
+		Schedule:           input.Schedule,
+		AlertMinScore:      input.AlertMinScore,
+		NotifyEmail:        input.NotifyEmail,
+		WebhookURL:         input.WebhookURL,
+		WebhookSecret:      input.WebhookSecret,
+		Enabled:            input.Enabled,
+	}
+	if search.Enabled {
+		next := schedule.Next(time.Now())
+		search.NextRunAt = &next
+	}
+
+	if err := s.searches.CreateSearch(ctx, search); err != nil {
+		return nil, fmt.Errorf("failed to save search: %w", err)
+	}
+
+	response := &dtos.CreateSavedSearchResponse{SavedSearch: *search}
+	if generated {
+		response.OwnerToken = ownerToken
+	}
+	return response, nil
+}
+
+// ListSavedSearches returns the searches created with the given owner token.
+func (s *jobService) ListSavedSearches(ctx context.Context, ownerToken string) ([]dtos.SavedSearch, error) {
+	searches, err := s.searches.ListSearches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := []dtos.SavedSearch{}
+	for _, search := range searches {
+		if ownsSearch(search, ownerToken) {
+			owned = append(owned, search)
+		}
+	}
+	return owned, nil
+}
+
+func (s *jobService) GetSavedSearch(ctx context.Context, ownerToken, id string) (*dtos.SavedSearch, error) {
+	return s.ownedSearch(ctx, ownerToken, id)
+}
+
+func (s *jobService) DeleteSavedSearch(ctx context.Context, ownerToken, id string) error {
+	if _, err := s.ownedSearch(ctx, ownerToken, id); err != nil {
+		return err
+	}
+	return s.searches.DeleteSearch(ctx, id)
+}
+
+func (s *jobService) ListNotificationDeliveries(ctx context.Context, ownerToken, searchID string) ([]dtos.NotificationDelivery, error) {
+	if _, err := s.ownedSearch(ctx, ownerToken, searchID); err != nil {
+		return nil, err
+	}
+	return s.deliveries.ListDeliveries(ctx, searchID)
+}
+
+func (s *jobService) ListSavedSearchMatches(ctx context.Context, ownerToken, id string) ([]dtos.SavedSearchMatch, error) {
+	if _, err := s.ownedSearch(ctx, ownerToken, id); err != nil {
+		return nil, err
+	}
+	return s.searches.ListMatches(ctx, id)
+}
+
+func (s *jobService) RunSavedSearch(ctx context.Context, ownerToken, id string) (*dtos.SavedSearchRunResult, error) {
+	search, err := s.ownedSearch(ctx, ownerToken, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.runSavedSearch(ctx, search)
+}
+
+// ListScheduledSearches and RunScheduledSearch are the unscoped versions used
+// by the scheduler.
+func (s *jobService) ListScheduledSearches(ctx context.Context) ([]dtos.SavedSearch, error) {
+	return s.searches.ListSearches(ctx)
+}
+
+func (s *jobService) RunScheduledSearch(ctx context.Context, id string) (*dtos.SavedSearchRunResult, error) {
+	search, err := s.searches.GetSearch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.runSavedSearch(ctx, search)
+}
+
+// ownedSearch reports searches owned by someone else as not found so their
+// IDs cannot be probed.
+func (s *jobService) ownedSearch(ctx context.Context, ownerToken, id string) (*dtos.SavedSearch, error) {
+	search, err := s.searches.GetSearch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ownsSearch(*search, ownerToken) {
+		return nil, repo.ErrNotFound
+	}
+	return search, nil
+}
+
+func ownsSearch(search dtos.SavedSearch, ownerToken string) bool {
+	return ownerToken != "" && subtle.ConstantTimeCompare([]byte(search.OwnerTokenHash), []byte(hashOwnerToken(ownerToken))) == 1
+}
+
+func hashOwnerToken(ownerToken string) string {
+	sum := sha256.Sum256([]byte(ownerToken))
+	return hex.EncodeToString(sum[:])
+}
+
+func newOwnerToken() (string, error) {
+	b := make([]byte, ownerTokenRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate owner token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// runSavedSearch re-runs the search and ranking stages for a saved profile and
+// records ranked jobs whose URL has not been seen by this search before and
+// that score at least the search's alert threshold. It only ever uses the
+// key stored with the search, never the server's own key.
+func (s *jobService) runSavedSearch(ctx context.Context, search *dtos.SavedSearch) (*dtos.SavedSearchRunResult, error) {
+	id := search.ID
+	if search.APIKey == "" {
+		return nil, ErrSearchMissingKey
+	}
+	apiKey := search.APIKey
+
+	log := logger.FromContext(ctx).With("search_id", id)
+	ctx = usage.WithTracker(ctx, usage.NewTracker())
+
+	request := dtos.JobSearchRequest{
+		LocationPreference: search.LocationPreference,
+		Ranking:            search.Ranking,
+		Filters:            search.Filters,
+	}
+
+	start := time.Now()
+	rankedJobs, filtered, runErr := s.searchAndRank(ctx, search.OwnerTokenHash, search.Profile, request, apiKey)
+	metrics.ObserveStage(metrics.StageTotal, start, runErr)
+
+	search.LastRunAt = &start
+	search.LastError = ""
+	if schedule, err := scheduler.ParseSchedule(search.Schedule); err == nil && search.Enabled {
+		next := schedule.Next(time.Now())
+		search.NextRunAt = &next
+	}
+
+	if runErr != nil {
+		search.LastError = runErr.Error()
+		if err := s.searches.UpdateSearch(ctx, search); err != nil {
+			log.Warn("failed to record saved search failure", "error", err)
+		}
+		return nil, fmt.Errorf("failed to run saved search: %w", runErr)
+	}
+
+	run := &dtos.SearchRun{
+		Profile:            search.Profile,
+		LocationPreference: search.LocationPreference,
+		Jobs:               rankedJobs,
+		Filtered:           filtered,
+	}
+	if err := s.runs.SaveRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to save search run: %w", err)
+	}
+	search.LastRunID = run.ID
+
+	keys := make([]string, len(rankedJobs))
+	for i, rankedJob := range rankedJobs {
+		keys[i] = ai.JobURLKey(rankedJob.Job)
+	}
+	isNew, err := s.searches.MarkSeen(ctx, id, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record seen jobs: %w", err)
+	}
+
+	matches := []dtos.SavedSearchMatch{}
+	for i, rankedJob := range rankedJobs {
+		if isNew[i] && rankedJob.PercentMatch >= search.AlertMinScore {
+			matches = append(matches, dtos.SavedSearchMatch{
+				SearchID: id,
+				RunID:    run.ID,
+				FoundAt:  start,
+				Job:      rankedJob,
+			})
+		}
+	}
+	if err := s.searches.AddMatches(ctx, id, matches); err != nil {
+		return nil, fmt.Errorf("failed to save new matches: %w", err)
+	}
+
+	if err := s.searches.UpdateSearch(ctx, search); err != nil {
+		return nil, fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	log.Info("saved search completed", "run_id", run.ID, "jobs", len(rankedJobs), "new_matches", len(matches))
+
+	if len(matches) > 0 && s.notifier != nil {
+		notification := notify.Notification{Search: *search, RunID: run.ID, Matches: matches}
+		s.notifier.DispatchAsync(context.WithoutCancel(ctx), notification)
+	}
+
+	return &dtos.SavedSearchRunResult{
+		SearchID:   id,
+		RunID:      run.ID,
+		JobsRanked: len(rankedJobs),
+		NewMatches: matches,
+		Success:    true,
+	}, nil
+}
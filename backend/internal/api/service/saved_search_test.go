@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestSavedSearchesAreScopedToOwner(t *testing.T) {
+	ctx := context.Background()
+	runs := repo.NewRunRepository(10)
+	s := NewJobService(runs, repo.NewSavedSearchRepository(), repo.NewDeliveryRepository(10), repo.NewApplicationRepository(), nil)
+
+	run := &dtos.SearchRun{Profile: "Go developer"}
+	if err := runs.SaveRun(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := s.CreateSavedSearch(ctx, SavedSearchInput{Name: "Go", RunID: run.ID, Schedule: "@daily"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created.OwnerToken) < minOwnerTokenLength {
+		t.Fatalf("generated owner token %q is too short", created.OwnerToken)
+	}
+
+	other := "someone-else-entirely"
+	if _, err := s.CreateSavedSearch(ctx, SavedSearchInput{Name: "Other", RunID: run.ID, Schedule: "@daily", OwnerToken: other}); err != nil {
+		t.Fatal(err)
+	}
+
+	mine, err := s.ListSavedSearches(ctx, created.OwnerToken)
+	if err != nil || len(mine) != 1 || mine[0].ID != created.ID {
+		t.Fatalf("ListSavedSearches = %v, %v; want only the owner's search", mine, err)
+	}
+
+	if _, err := s.GetSavedSearch(ctx, other, created.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("GetSavedSearch with another token: err = %v, want ErrNotFound", err)
+	}
+	if err := s.DeleteSavedSearch(ctx, other, created.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("DeleteSavedSearch with another token: err = %v, want ErrNotFound", err)
+	}
+	if _, err := s.RunSavedSearch(ctx, "", created.ID); !errors.Is(err, repo.ErrNotFound) {
+		t.Errorf("RunSavedSearch without a token: err = %v, want ErrNotFound", err)
+	}
+
+	// No stored key means no run; the server's own key is never used.
+	if _, err := s.RunSavedSearch(ctx, created.OwnerToken, created.ID); !errors.Is(err, ErrSearchMissingKey) {
+		t.Errorf("RunSavedSearch without a stored key: err = %v, want ErrSearchMissingKey", err)
+	}
+
+	if _, err := s.CreateSavedSearch(ctx, SavedSearchInput{Name: "Short", RunID: run.ID, Schedule: "@daily", OwnerToken: "short"}); !errors.Is(err, ErrInvalidOwnerToken) {
+		t.Errorf("short owner token: err = %v, want ErrInvalidOwnerToken", err)
+	}
+	if _, err := s.CreateSavedSearch(ctx, SavedSearchInput{Name: "Bad", RunID: run.ID, Schedule: "* * * * *"}); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("bad schedule: err = %v, want ErrInvalidSchedule", err)
+	}
+}
@@ -3,48 +3,224 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/lakshya1goel/job-assistance/config"
 	"github.com/lakshya1goel/job-assistance/internal/ai"
+	"github.com/lakshya1goel/job-assistance/internal/analysis"
+	"github.com/lakshya1goel/job-assistance/internal/api/repo"
 	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/filter"
+	"github.com/lakshya1goel/job-assistance/internal/jobpage"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+	"github.com/lakshya1goel/job-assistance/internal/metrics"
+	"github.com/lakshya1goel/job-assistance/internal/notify"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"github.com/lakshya1goel/job-assistance/internal/usage"
 )
 
 type JobService interface {
-	FetchAndRankStructuredJobs(ctx context.Context, pdfBytes []byte, locationPreference dtos.LocationPreference, apiKey string) ([]dtos.RankedJob, error)
+	FetchAndRankStructuredJobs(ctx context.Context, ownerToken string, pdfBytes []byte, request dtos.JobSearchRequest, apiKey string) (*dtos.SearchRun, *dtos.UsageSummary, error)
+	GetSkillGaps(ctx context.Context, runID string, limit int) (*dtos.SkillGapReport, error)
+	GetSearchRun(ctx context.Context, runID string) (*dtos.SearchRun, error)
+	CreateSavedSearch(ctx context.Context, input SavedSearchInput) (*dtos.CreateSavedSearchResponse, error)
+	ListSavedSearches(ctx context.Context, ownerToken string) ([]dtos.SavedSearch, error)
+	GetSavedSearch(ctx context.Context, ownerToken, id string) (*dtos.SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, ownerToken, id string) error
+	RunSavedSearch(ctx context.Context, ownerToken, id string) (*dtos.SavedSearchRunResult, error)
+	ListSavedSearchMatches(ctx context.Context, ownerToken, id string) ([]dtos.SavedSearchMatch, error)
+	ListNotificationDeliveries(ctx context.Context, ownerToken, searchID string) ([]dtos.NotificationDelivery, error)
+	ListScheduledSearches(ctx context.Context) ([]dtos.SavedSearch, error)
+	RunScheduledSearch(ctx context.Context, id string) (*dtos.SavedSearchRunResult, error)
+	CreateApplication(ctx context.Context, ownerToken string, request dtos.CreateApplicationRequest) (*dtos.Application, error)
+	ListApplications(ctx context.Context, ownerToken, status, tag string) ([]dtos.Application, error)
+	GetApplication(ctx context.Context, ownerToken, id string) (*dtos.Application, error)
+	UpdateApplication(ctx context.Context, ownerToken, id string, request dtos.UpdateApplicationRequest) (*dtos.Application, error)
+	DeleteApplication(ctx context.Context, ownerToken, id string) error
+	GenerateCoverLetter(ctx context.Context, ownerToken, applicationID string, source ProfileSource, options dtos.CoverLetterOptions, apiKey string) (*dtos.CoverLetterResponse, error)
+	TailorResume(ctx context.Context, ownerToken, applicationID string, source ProfileSource, apiKey string) (*dtos.ResumeTailoringResponse, error)
+	ATSReports(ctx context.Context, input ATSInput) ([]dtos.ATSJobReport, error)
+	GenerateInterviewPrep(ctx context.Context, ownerToken, applicationID string, source ProfileSource, apiKey string) (*dtos.InterviewPrepResponse, error)
+	ScoreJob(ctx context.Context, input JobScoreInput) (*dtos.JobScoreResponse, error)
+	RankJobs(ctx context.Context, ownerToken string, request dtos.RankJobsRequest) (*dtos.SearchRun, *dtos.UsageSummary, error)
 }
 
 type jobService struct {
+	rankingDefaults   dtos.RankingOptions
+	fxRates           map[string]float64
+	modelPrices       map[string]config.ModelPrice
+	embeddingProvider string
+	newRankingClient  func(ctx context.Context, apiKey string) *ai.RankingClient
+	runs              repo.RunRepository
+	searches          repo.SavedSearchRepository
+	deliveries        repo.DeliveryRepository
+	applications      repo.ApplicationRepository
+	notifier          *notify.Dispatcher
+	pages             *jobpage.Fetcher
 }
 
-func NewJobService() JobService {
-	return &jobService{}
+func NewJobService(runs repo.RunRepository, searches repo.SavedSearchRepository, deliveries repo.DeliveryRepository, applications repo.ApplicationRepository, notifier *notify.Dispatcher) JobService {
+	return &jobService{
+		rankingDefaults:   rankingDefaultsFromEnv(),
+		fxRates:           config.GetFXRates(),
+		modelPrices:       config.GetModelPrices(),
+		embeddingProvider: config.GetEmbeddingProvider(),
+		newRankingClient:  ai.NewRerankingClient,
+		runs:              runs,
+		searches:          searches,
+		deliveries:        deliveries,
+		applications:      applications,
+		notifier:          notifier,
+		pages:             newJobPageFetcher(),
+	}
+}
+
+// rankingDefaultsFromEnv applies the RANKING_* variables on top of the ai
+// package defaults.
+func rankingDefaultsFromEnv() dtos.RankingOptions {
+	return ai.MergeRankingOptions(ai.DefaultRankingOptions, dtos.RankingOverrides{
+		MaxJobs:        config.GetOptionalInt("RANKING_MAX_JOBS"),
+		BatchSize:      config.GetOptionalInt("RANKING_BATCH_SIZE"),
+		MaxConcurrency: config.GetOptionalInt("RANKING_CONCURRENCY"),
+		MinScore:       config.GetOptionalFloat("RANKING_MIN_SCORE"),
+		TopN:           config.GetOptionalInt("RANKING_TOP_N"),
+		AnchorJobs:     config.GetOptionalInt("RANKING_ANCHOR_JOBS"),
+		PreRankTopK:    config.GetOptionalInt("RANKING_PRERANK_TOP_K"),
+		Weights: &dtos.ScoreWeightOverrides{
+			Title:     config.GetOptionalFloat("RANKING_WEIGHT_TITLE"),
+			Skills:    config.GetOptionalFloat("RANKING_WEIGHT_SKILLS"),
+			Seniority: config.GetOptionalFloat("RANKING_WEIGHT_SENIORITY"),
+			Location:  config.GetOptionalFloat("RANKING_WEIGHT_LOCATION"),
+			Domain:    config.GetOptionalFloat("RANKING_WEIGHT_DOMAIN"),
+		},
+	})
+}
+
+// rankingClient builds a ranking client, swapping in the local embedder when
+// EMBEDDING_PROVIDER=local.
+func (s *jobService) rankingClient(ctx context.Context, apiKey string) *ai.RankingClient {
+	client := s.newRankingClient(ctx, apiKey)
+	if s.embeddingProvider == "local" {
+		client.Embedder = ai.NewBagOfWordsEmbedder()
+	}
+	return client
+}
+
+func newJobPageFetcher() *jobpage.Fetcher {
+	fetchConfig := config.GetJobFetchConfig()
+	return jobpage.NewFetcher(jobpage.Options{
+		Timeout:      fetchConfig.Timeout,
+		MaxBytes:     fetchConfig.MaxBytes,
+		UserAgent:    fetchConfig.UserAgent,
+		AllowPrivate: fetchConfig.AllowPrivate,
+	})
+}
+
+// FetchAndRankStructuredJobs runs a full search. An owner token is optional
+// and only used to flag jobs already on the caller's application board.
+func (s *jobService) FetchAndRankStructuredJobs(ctx context.Context, ownerToken string, pdfBytes []byte, request dtos.JobSearchRequest, apiKey string) (*dtos.SearchRun, *dtos.UsageSummary, error) {
+	tracker := usage.NewTracker()
+	ctx = usage.WithTracker(ctx, tracker)
+
+	start := time.Now()
+	profile, rankedJobs, filtered, err := s.fetchAndRank(ctx, trackingOwner(ownerToken), pdfBytes, request, apiKey)
+	metrics.ObserveStage(metrics.StageTotal, start, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	run := &dtos.SearchRun{
+		Profile:            profile,
+		LocationPreference: request.LocationPreference,
+		Jobs:               rankedJobs,
+		Filtered:           filtered,
+	}
+	if err := s.runs.SaveRun(ctx, run); err != nil {
+		return nil, nil, fmt.Errorf("failed to save search run: %w", err)
+	}
+
+	return run, tracker.Summary(s.modelPrices), nil
 }
 
-func (s *jobService) FetchAndRankStructuredJobs(ctx context.Context, pdfBytes []byte, locationPreference dtos.LocationPreference, apiKey string) ([]dtos.RankedJob, error) {
+func (s *jobService) GetSkillGaps(ctx context.Context, runID string, limit int) (*dtos.SkillGapReport, error) {
+	run, err := s.runs.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := analysis.SkillGaps(run, limit)
+	return &report, nil
+}
+
+func (s *jobService) GetSearchRun(ctx context.Context, runID string) (*dtos.SearchRun, error) {
+	return s.runs.GetRun(ctx, runID)
+}
+
+func (s *jobService) fetchAndRank(ctx context.Context, ownerHash string, pdfBytes []byte, request dtos.JobSearchRequest, apiKey string) (string, []dtos.RankedJob, map[string]int, error) {
+	profile, err := s.extractProfile(ctx, pdfBytes, request.LocationPreference, apiKey)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	rankedJobs, filtered, err := s.searchAndRank(ctx, ownerHash, profile, request, apiKey)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return profile, rankedJobs, filtered, nil
+}
+
+func (s *jobService) extractProfile(ctx context.Context, pdfBytes []byte, locationPreference dtos.LocationPreference, apiKey string) (string, error) {
 	profileClient := ai.NewProfileClient(ctx, apiKey)
-	aiClient := ai.NewAIClient(ctx, apiKey)
-	rankingClient := ai.NewRerankingClient(ctx, apiKey)
 
-	profile, err := profileClient.ExtractCandidateProfile(ctx, pdfBytes, locationPreference)
+	stageStart := time.Now()
+	stageCtx, span := tracing.Start(ctx, "stage."+metrics.StageProfileExtraction)
+	profile, err := profileClient.ExtractCandidateProfile(stageCtx, pdfBytes, locationPreference)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageProfileExtraction, stageStart, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract candidate profile: %w", err)
+		return "", fmt.Errorf("failed to extract candidate profile: %w", err)
 	}
 
-	fmt.Println("Profile: ", profile)
+	logger.FromContext(ctx).Debug("extracted candidate profile", "profile", logger.Sensitive(profile))
+	return profile, nil
+}
+
+func (s *jobService) searchAndRank(ctx context.Context, ownerHash, profile string, request dtos.JobSearchRequest, apiKey string) ([]dtos.RankedJob, map[string]int, error) {
+	log := logger.FromContext(ctx)
+	aiClient := ai.NewAIClient(ctx, apiKey)
+	rankingClient := s.rankingClient(ctx, apiKey)
 
-	jobs, err := aiClient.GetJobsFromResume(ctx, profile)
+	rankingOptions := ai.NormalizeRankingOptions(ai.MergeRankingOptions(s.rankingDefaults, request.Ranking))
+
+	stageStart := time.Now()
+	stageCtx, span := tracing.Start(ctx, "stage."+metrics.StageJobSearch)
+	jobs, err := aiClient.GetJobsFromResume(stageCtx, profile, rankingOptions.MaxJobs)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageJobSearch, stageStart, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get structured jobs from resume: %w", err)
+		return nil, nil, fmt.Errorf("failed to get structured jobs from resume: %w", err)
+	}
+
+	jobs, filtered := filter.Apply(jobs, request.Filters, time.Now(), s.fxRates)
+	if len(filtered) > 0 {
+		log.Info("filtered jobs before ranking", "filtered", filtered, "remaining", len(jobs))
 	}
 
 	if len(jobs) == 0 {
-		fmt.Println("No jobs found from structured search")
-		return []dtos.RankedJob{}, nil
+		log.Info("no jobs found from structured search")
+		return []dtos.RankedJob{}, filtered, nil
 	}
 
-	fmt.Printf("Re-ranking %d structured jobs based on resume relevance...\n", len(jobs))
-	rankedJobs, err := rankingClient.RerankJobs(ctx, profile, jobs)
+	log.Info("re-ranking structured jobs based on resume relevance", "jobs", len(jobs))
+	stageStart = time.Now()
+	stageCtx, span = tracing.Start(ctx, "stage."+metrics.StageRanking)
+	rankedJobs, err := rankingClient.RerankJobs(stageCtx, profile, jobs, rankingOptions)
+	tracing.End(span, err)
+	metrics.ObserveStage(metrics.StageRanking, stageStart, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to rank structured jobs: %w", err)
+		return nil, nil, fmt.Errorf("failed to rank structured jobs: %w", err)
 	}
-	return rankedJobs, nil
+
+	s.markTrackedJobs(ctx, ownerHash, rankedJobs)
+	return rankedJobs, filtered, nil
 }
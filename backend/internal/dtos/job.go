@@ -12,6 +12,7 @@ type JobDescription struct {
 	ExperienceLevel string   `json:"experience_level"`
 	RequiredSkills  []string `json:"required_skills"`
 	Remote          bool     `json:"remote"`
+	PostedDate      *string  `json:"posted_date,omitempty"`
 	Location        *string  `json:"location,omitempty"`
 	Salary          *int     `json:"salary,omitempty"`
 	JobPostURL      string   `json:"job_post_url"`
@@ -48,12 +49,45 @@ type JobMatchResults struct {
 }
 
 type JSearchJob struct {
-	Title       string `json:"job_title"`
-	Company     string `json:"employer_name"`
-	IsRemote    bool   `json:"job_is_remote"`
-	Location    string `json:"job_city"`
-	Description string `json:"job_description"`
-	URL         string `json:"job_apply_link"`
+	Title          string               `json:"job_title"`
+	Company        string               `json:"employer_name"`
+	EmployerLogo   string               `json:"employer_logo"`
+	IsRemote       bool                 `json:"job_is_remote"`
+	Location       string               `json:"job_city"`
+	State          string               `json:"job_state"`
+	Country        string               `json:"job_country"`
+	Description    string               `json:"job_description"`
+	URL            string               `json:"job_apply_link"`
+	PostedAt       string               `json:"job_posted_at_datetime_utc"`
+	EmploymentType string               `json:"job_employment_type"`
+	MinSalary      *float64             `json:"job_min_salary"`
+	MaxSalary      *float64             `json:"job_max_salary"`
+	SalaryCurrency string               `json:"job_salary_currency"`
+	SalaryPeriod   string               `json:"job_salary_period"`
+	Highlights     *JSearchHighlights   `json:"job_highlights"`
+	ApplyOptions   []JSearchApplyOption `json:"apply_options"`
+}
+
+type JSearchParams struct {
+	Query           string
+	Page            int
+	NumPages        int
+	DatePosted      string
+	EmploymentTypes string
+	JobRequirements string
+	Radius          float64
+}
+
+type JSearchHighlights struct {
+	Qualifications   []string `json:"Qualifications"`
+	Responsibilities []string `json:"Responsibilities"`
+	Benefits         []string `json:"Benefits"`
+}
+
+type JSearchApplyOption struct {
+	Publisher string `json:"publisher"`
+	ApplyLink string `json:"apply_link"`
+	IsDirect  bool   `json:"is_direct"`
 }
 
 type LinkupJob struct {
@@ -64,12 +98,42 @@ type LinkupJob struct {
 }
 
 type Job struct {
-	Title       string `json:"title"`
-	Company     string `json:"company,omitempty"`
-	Location    string `json:"location,omitempty"`
-	Description string `json:"description,omitempty"`
-	URL         string `json:"url"`
-	Source      string `json:"source"`
+	Title          string         `json:"title"`
+	Company        string         `json:"company,omitempty"`
+	Location       string         `json:"location,omitempty"`
+	State          string         `json:"state,omitempty"`
+	Country        string         `json:"country,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	RequiredSkills []string       `json:"required_skills,omitempty"`
+	URL            string         `json:"url"`
+	Source         string         `json:"source"`
+	PostedAt       *time.Time     `json:"posted_at,omitempty"`
+	EmploymentType string         `json:"employment_type,omitempty"`
+	Salary         *Salary        `json:"salary,omitempty"`
+	EmployerLogo   string         `json:"employer_logo,omitempty"`
+	Highlights     *JobHighlights `json:"highlights,omitempty"`
+	ApplyOptions   []ApplyOption  `json:"apply_options,omitempty"`
+}
+
+type Salary struct {
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	Currency  string   `json:"currency,omitempty"`
+	Period    string   `json:"period,omitempty"`
+	AnnualMin *float64 `json:"annual_min,omitempty"`
+	AnnualMax *float64 `json:"annual_max,omitempty"`
+}
+
+type JobHighlights struct {
+	Qualifications   []string `json:"qualifications,omitempty"`
+	Responsibilities []string `json:"responsibilities,omitempty"`
+	Benefits         []string `json:"benefits,omitempty"`
+}
+
+type ApplyOption struct {
+	Publisher string `json:"publisher"`
+	URL       string `json:"url"`
+	IsDirect  bool   `json:"is_direct"`
 }
 
 type ErrorResponse struct {
@@ -79,26 +143,412 @@ type ErrorResponse struct {
 }
 
 type JobSearchResult struct {
-	Jobs   []Job
-	Error  error
-	Source string
+	Jobs    []Job
+	Error   error
+	Source  string
+	JSearch *JSearchParams
+}
+
+type MatchBreakdown struct {
+	TitleFit     float64 `json:"title_fit"`
+	SkillsFit    float64 `json:"skills_fit"`
+	SeniorityFit float64 `json:"seniority_fit"`
+	LocationFit  float64 `json:"location_fit"`
+	DomainFit    float64 `json:"domain_fit"`
+}
+
+type ScoreWeights struct {
+	Title     float64 `json:"title"`
+	Skills    float64 `json:"skills"`
+	Seniority float64 `json:"seniority"`
+	Location  float64 `json:"location"`
+	Domain    float64 `json:"domain"`
+}
+
+type ScoreWeightOverrides struct {
+	Title     *float64 `json:"title,omitempty"`
+	Skills    *float64 `json:"skills,omitempty"`
+	Seniority *float64 `json:"seniority,omitempty"`
+	Location  *float64 `json:"location,omitempty"`
+	Domain    *float64 `json:"domain,omitempty"`
 }
 
 type RankedJob struct {
-	Job             Job      `json:"job"`
-	PercentMatch    float64  `json:"percent_match"`
-	MatchReason     string   `json:"match_reason"`
-	SkillsMatched   []string `json:"skills_matched"`
-	ExperienceMatch string   `json:"experience_match"`
+	Job             Job             `json:"job"`
+	PercentMatch    float64         `json:"percent_match"`
+	RawPercentMatch float64         `json:"raw_percent_match,omitempty"`
+	EmbeddingScore  *float64        `json:"embedding_score,omitempty"`
+	Breakdown       *MatchBreakdown `json:"breakdown,omitempty"`
+	MatchReason     string          `json:"match_reason"`
+	SkillsMatched   []string        `json:"skills_matched"`
+	MissingSkills   []string        `json:"missing_skills"`
+	ExperienceMatch string          `json:"experience_match"`
+	// Set when the job is already on the application board.
+	ApplicationID     string `json:"application_id,omitempty"`
+	ApplicationStatus string `json:"application_status,omitempty"`
+}
+
+type StageUsage struct {
+	Calls          int `json:"calls"`
+	PromptTokens   int `json:"prompt_tokens"`
+	ResponseTokens int `json:"response_tokens"`
+	TotalTokens    int `json:"total_tokens"`
+}
+
+type UsageSummary struct {
+	Stages             map[string]StageUsage `json:"stages"`
+	LLMCalls           int                   `json:"llm_calls"`
+	PromptTokens       int                   `json:"prompt_tokens"`
+	ResponseTokens     int                   `json:"response_tokens"`
+	TotalTokens        int                   `json:"total_tokens"`
+	EstimatedCostUSD   float64               `json:"estimated_cost_usd"`
+	UnpricedModels     []string              `json:"unpriced_models,omitempty"`
+	ExternalCalls      map[string]int        `json:"external_calls"`
+	TotalExternalCalls int                   `json:"total_external_calls"`
+}
+
+type SearchRun struct {
+	ID                 string             `json:"id"`
+	CreatedAt          time.Time          `json:"created_at"`
+	Profile            string             `json:"-"`
+	LocationPreference LocationPreference `json:"location_preference"`
+	Jobs               []RankedJob        `json:"jobs"`
+	Filtered           map[string]int     `json:"filtered,omitempty"`
+}
+
+type SkillGapExample struct {
+	Title        string  `json:"title"`
+	Company      string  `json:"company,omitempty"`
+	URL          string  `json:"url"`
+	PercentMatch float64 `json:"percent_match"`
+}
+
+type SkillGap struct {
+	Skill         string            `json:"skill"`
+	MissingIn     int               `json:"missing_in"`
+	WeightedScore float64           `json:"weighted_score"`
+	ExampleJobs   []SkillGapExample `json:"example_jobs"`
+}
+
+type SkillGapReport struct {
+	RunID           string     `json:"run_id"`
+	JobsAnalyzed    int        `json:"jobs_analyzed"`
+	CandidateSkills []string   `json:"candidate_skills"`
+	Gaps            []SkillGap `json:"gaps"`
+	Success         bool       `json:"success"`
 }
 
 type JobSearchResponse struct {
-	Jobs    []RankedJob `json:"jobs"`
-	Total   int         `json:"total"`
-	Success bool        `json:"success"`
+	RunID    string         `json:"run_id,omitempty"`
+	Jobs     []RankedJob    `json:"jobs"`
+	Total    int            `json:"total"`
+	Filtered map[string]int `json:"filtered,omitempty"`
+	Usage    *UsageSummary  `json:"usage,omitempty"`
+	Success  bool           `json:"success"`
+}
+
+type RankingOptions struct {
+	MaxJobs        int          `json:"max_jobs"`
+	BatchSize      int          `json:"batch_size"`
+	MaxConcurrency int          `json:"max_concurrency"`
+	MinScore       float64      `json:"min_score"`
+	TopN           int          `json:"top_n"`
+	AnchorJobs     int          `json:"anchor_jobs"`
+	PreRankTopK    int          `json:"prerank_top_k"`
+	Weights        ScoreWeights `json:"weights"`
+}
+
+type RankingOverrides struct {
+	MaxJobs        *int                  `json:"max_jobs,omitempty"`
+	BatchSize      *int                  `json:"batch_size,omitempty"`
+	MaxConcurrency *int                  `json:"max_concurrency,omitempty"`
+	MinScore       *float64              `json:"min_score,omitempty"`
+	TopN           *int                  `json:"top_n,omitempty"`
+	AnchorJobs     *int                  `json:"anchor_jobs,omitempty"`
+	PreRankTopK    *int                  `json:"prerank_top_k,omitempty"`
+	Weights        *ScoreWeightOverrides `json:"weights,omitempty"`
+}
+
+type JobFilters struct {
+	MaxAgeDays           int      `json:"max_age_days,omitempty"`
+	MinSalary            float64  `json:"min_salary,omitempty"`
+	Currency             string   `json:"currency,omitempty"`
+	ExcludeCompanies     []string `json:"exclude_companies,omitempty"`
+	ExcludeTitleKeywords []string `json:"exclude_titles,omitempty"`
+	ExcludeKeywords      []string `json:"exclude_keywords,omitempty"`
+	ExcludeDomains       []string `json:"exclude_domains,omitempty"`
+	RequiredSkills       []string `json:"required_skills,omitempty"`
+	EmploymentTypes      []string `json:"employment_types,omitempty"`
+}
+
+type JobSearchRequest struct {
+	LocationPreference LocationPreference
+	Ranking            RankingOverrides
+	Filters            JobFilters
 }
 
 type BatchResult struct {
-	Jobs       []RankedJob
-	BatchIndex int
+	Jobs         []RankedJob
+	Evaluated    []bool
+	AnchorJobs   []RankedJob
+	AnchorScores map[int]float64
+	BatchIndex   int
+	Fallback     bool
+}
+
+type SavedSearch struct {
+	ID                 string             `json:"id"`
+	Name               string             `json:"name"`
+	CreatedAt          time.Time          `json:"created_at"`
+	Profile            string             `json:"-"`
+	APIKey             string             `json:"-"`
+	OwnerTokenHash     string             `json:"-"`
+	LocationPreference LocationPreference `json:"location_preference"`
+	Filters            JobFilters         `json:"filters"`
+	Ranking            RankingOverrides   `json:"ranking"`
+	Schedule           string             `json:"schedule"`
+	AlertMinScore      float64            `json:"alert_min_score"`
+	NotifyEmail        string             `json:"notify_email,omitempty"`
+	WebhookURL         string             `json:"-"`
+	WebhookSecret      string             `json:"-"`
+	Enabled            bool               `json:"enabled"`
+	LastRunAt          *time.Time         `json:"last_run_at,omitempty"`
+	NextRunAt          *time.Time         `json:"next_run_at,omitempty"`
+	LastRunID          string             `json:"last_run_id,omitempty"`
+	LastError          string             `json:"last_error,omitempty"`
+}
+
+// CreateSavedSearchResponse includes the owner token only when the server
+// generated it; it is not stored and cannot be retrieved again.
+type CreateSavedSearchResponse struct {
+	SavedSearch
+	OwnerToken string `json:"owner_token,omitempty"`
+}
+
+type SavedSearchMatch struct {
+	SearchID string    `json:"search_id"`
+	RunID    string    `json:"run_id"`
+	FoundAt  time.Time `json:"found_at"`
+	Job      RankedJob `json:"job"`
+}
+
+type SavedSearchRunResult struct {
+	SearchID   string             `json:"search_id"`
+	RunID      string             `json:"run_id"`
+	JobsRanked int                `json:"jobs_ranked"`
+	NewMatches []SavedSearchMatch `json:"new_matches"`
+	Success    bool               `json:"success"`
+}
+
+type SavedSearchListResponse struct {
+	Searches []SavedSearch `json:"searches"`
+	Total    int           `json:"total"`
+	Success  bool          `json:"success"`
+}
+
+type SavedSearchMatchesResponse struct {
+	SearchID string             `json:"search_id"`
+	Matches  []SavedSearchMatch `json:"matches"`
+	Total    int                `json:"total"`
+	Success  bool               `json:"success"`
+}
+
+type NotificationDelivery struct {
+	ID          string    `json:"id"`
+	SearchID    string    `json:"search_id"`
+	RunID       string    `json:"run_id"`
+	Channel     string    `json:"channel"`
+	Target      string    `json:"target"`
+	Matches     int       `json:"matches"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type NotificationDeliveriesResponse struct {
+	SearchID   string                 `json:"search_id"`
+	Deliveries []NotificationDelivery `json:"deliveries"`
+	Total      int                    `json:"total"`
+	Success    bool                   `json:"success"`
+}
+
+type ApplicationStatusChange struct {
+	Status    string    `json:"status"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type Application struct {
+	ID             string                    `json:"id"`
+	OwnerTokenHash string                    `json:"-"`
+	JobKey         string                    `json:"-"`
+	Profile        string                    `json:"-"`
+	Job            RankedJob                 `json:"job"`
+	RunID          string                    `json:"run_id,omitempty"`
+	Status         string                    `json:"status"`
+	Notes          string                    `json:"notes,omitempty"`
+	Tags           []string                  `json:"tags"`
+	AppliedAt      *time.Time                `json:"applied_at,omitempty"`
+	FollowUpAt     *time.Time                `json:"follow_up_at,omitempty"`
+	StatusHistory  []ApplicationStatusChange `json:"status_history"`
+	InterviewPrep  *InterviewPrep            `json:"interview_prep,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// CreateApplicationRequest saves either the given job or, when RunID and
+// JobURL are set, the matching ranked job from a stored search run.
+type CreateApplicationRequest struct {
+	Job        *RankedJob `json:"job"`
+	RunID      string     `json:"run_id"`
+	JobURL     string     `json:"job_url"`
+	Status     string     `json:"status"`
+	Notes      string     `json:"notes"`
+	Tags       []string   `json:"tags"`
+	AppliedAt  *time.Time `json:"applied_at"`
+	FollowUpAt *time.Time `json:"follow_up_at"`
+}
+
+// UpdateApplicationRequest only changes the fields that are present.
+type UpdateApplicationRequest struct {
+	Status     *string    `json:"status"`
+	Notes      *string    `json:"notes"`
+	Tags       *[]string  `json:"tags"`
+	AppliedAt  *time.Time `json:"applied_at"`
+	FollowUpAt *time.Time `json:"follow_up_at"`
+}
+
+type ApplicationListResponse struct {
+	Applications []Application  `json:"applications"`
+	Total        int            `json:"total"`
+	ByStatus     map[string]int `json:"by_status"`
+	Success      bool           `json:"success"`
+}
+
+type CoverLetterOptions struct {
+	Tone   string `json:"tone"`
+	Length string `json:"length"`
+}
+
+type CoverLetterResponse struct {
+	ApplicationID string        `json:"application_id"`
+	Tone          string        `json:"tone"`
+	Length        string        `json:"length"`
+	CoverLetter   string        `json:"cover_letter"`
+	WordCount     int           `json:"word_count"`
+	Usage         *UsageSummary `json:"usage,omitempty"`
+	Success       bool          `json:"success"`
+}
+
+type BulletRewrite struct {
+	Section   string `json:"section,omitempty"`
+	Original  string `json:"original"`
+	Suggested string `json:"suggested"`
+	Reason    string `json:"reason"`
+}
+
+type KeywordSuggestion struct {
+	Keyword           string `json:"keyword"`
+	Placement         string `json:"placement,omitempty"`
+	SupportedByResume bool   `json:"supported_by_resume"`
+	Note              string `json:"note,omitempty"`
+}
+
+type ResumeTailoring struct {
+	Summary            string              `json:"summary"`
+	BulletRewrites     []BulletRewrite     `json:"bullet_rewrites"`
+	KeywordsToAdd      []KeywordSuggestion `json:"keywords_to_add"`
+	SectionOrder       []string            `json:"section_order"`
+	SectionOrderReason string              `json:"section_order_reason,omitempty"`
+}
+
+type ResumeTailoringResponse struct {
+	ApplicationID string `json:"application_id"`
+	ResumeTailoring
+	Usage   *UsageSummary `json:"usage,omitempty"`
+	Success bool          `json:"success"`
+}
+
+type ATSKeyword struct {
+	Keyword  string `json:"keyword"`
+	Kind     string `json:"kind"`
+	Required bool   `json:"required"`
+	// Related names a resume skill that partially covers a missing one.
+	Related string `json:"related,omitempty"`
+}
+
+type ATSRequirement struct {
+	Requirement string   `json:"requirement"`
+	Type        string   `json:"type"`
+	Met         bool     `json:"met"`
+	Missing     []string `json:"missing,omitempty"`
+}
+
+type ATSReport struct {
+	Score                   float64          `json:"score"`
+	SkillCoverage           float64          `json:"skill_coverage"`
+	TermCoverage            float64          `json:"term_coverage"`
+	MatchedKeywords         []ATSKeyword     `json:"matched_keywords"`
+	MissingKeywords         []ATSKeyword     `json:"missing_keywords"`
+	HardRequirements        []ATSRequirement `json:"hard_requirements"`
+	MissingHardRequirements int              `json:"missing_hard_requirements"`
+	ResumeWords             int              `json:"resume_words"`
+}
+
+type ATSJobReport struct {
+	Job RankedJob `json:"job"`
+	ATS ATSReport `json:"ats"`
+}
+
+type ATSReportResponse struct {
+	Reports []ATSJobReport `json:"reports"`
+	Total   int            `json:"total"`
+	Success bool           `json:"success"`
+}
+
+type InterviewQuestion struct {
+	Question   string `json:"question"`
+	Why        string `json:"why,omitempty"`
+	AnswerHint string `json:"answer_hint,omitempty"`
+}
+
+type InterviewTopic struct {
+	Topic  string  `json:"topic"`
+	Weight float64 `json:"weight"`
+	// SkillGap is set when the topic covers a skill the candidate lacks.
+	SkillGap bool   `json:"skill_gap"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type InterviewPrep struct {
+	TechnicalQuestions  []InterviewQuestion `json:"technical_questions"`
+	BehavioralQuestions []InterviewQuestion `json:"behavioral_questions"`
+	TopicsToReview      []InterviewTopic    `json:"topics_to_review"`
+	QuestionsToAsk      []string            `json:"questions_to_ask"`
+	GeneratedAt         time.Time           `json:"generated_at"`
+}
+
+type InterviewPrepResponse struct {
+	ApplicationID string `json:"application_id"`
+	InterviewPrep
+	Usage   *UsageSummary `json:"usage,omitempty"`
+	Success bool          `json:"success"`
+}
+
+type JobScoreResponse struct {
+	Job              RankedJob     `json:"job"`
+	ExtractionMethod string        `json:"extraction_method"`
+	Usage            *UsageSummary `json:"usage,omitempty"`
+	Success          bool          `json:"success"`
+}
+
+// RankJobsRequest ranks jobs supplied by the caller. The profile is given
+// either as free text or as a ResumeProfile.
+type RankJobsRequest struct {
+	APIKey            string           `json:"api_key"`
+	Profile           string           `json:"profile,omitempty"`
+	StructuredProfile *ResumeProfile   `json:"structured_profile,omitempty"`
+	Jobs              []Job            `json:"jobs"`
+	Ranking           RankingOverrides `json:"ranking"`
 }
@@ -0,0 +1,110 @@
+// Package export renders ranked jobs and the application board as CSV, JSON
+// Lines and iCalendar files.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatICS   = "ics"
+)
+
+var ContentTypes = map[string]string{
+	FormatCSV:   "text/csv; charset=utf-8",
+	FormatJSONL: "application/x-ndjson",
+	FormatICS:   "text/calendar; charset=utf-8",
+}
+
+var jobColumns = []string{"title", "company", "location", "url", "source", "percent_match", "skills_matched", "reason"}
+
+var applicationColumns = []string{"status", "tags", "applied_at", "follow_up_at", "notes"}
+
+func RankedJobsCSV(w io.Writer, rankedJobs []dtos.RankedJob) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(jobColumns); err != nil {
+		return err
+	}
+	for _, rankedJob := range rankedJobs {
+		if err := writer.Write(sanitizeRow(jobRow(rankedJob))); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ApplicationsCSV writes the ranked job columns followed by the board's
+// tracking columns.
+func ApplicationsCSV(w io.Writer, applications []dtos.Application) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append(append([]string{}, jobColumns...), applicationColumns...)); err != nil {
+		return err
+	}
+	for _, application := range applications {
+		row := append(jobRow(application.Job),
+			application.Status,
+			strings.Join(application.Tags, "; "),
+			formatDate(application.AppliedAt),
+			formatDate(application.FollowUpAt),
+			application.Notes,
+		)
+		if err := writer.Write(sanitizeRow(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// JSONLines writes one JSON document per line.
+func JSONLines[T any](w io.Writer, items []T) error {
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("failed to encode JSON line: %w", err)
+		}
+	}
+	return nil
+}
+
+func jobRow(rankedJob dtos.RankedJob) []string {
+	return []string{
+		rankedJob.Job.Title,
+		rankedJob.Job.Company,
+		rankedJob.Job.Location,
+		rankedJob.Job.URL,
+		rankedJob.Job.Source,
+		strconv.FormatFloat(rankedJob.PercentMatch, 'f', -1, 64),
+		strings.Join(rankedJob.SkillsMatched, "; "),
+		rankedJob.MatchReason,
+	}
+}
+
+// sanitizeRow stops spreadsheet applications from evaluating job data
+// scraped from third-party listings as formulas.
+func sanitizeRow(row []string) []string {
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			row[i] = "'" + cell
+		}
+	}
+	return row
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestSanitizeRow(t *testing.T) {
+	row := []string{"=HYPERLINK(\"x\")", "+1", "-2", "@SUM(A1)", "\tcell", "\rcell", "plain", "", "a=b"}
+	want := []string{"'=HYPERLINK(\"x\")", "'+1", "'-2", "'@SUM(A1)", "'\tcell", "'\rcell", "plain", "", "a=b"}
+
+	if got := sanitizeRow(row); !slices.Equal(got, want) {
+		t.Errorf("sanitizeRow() = %q, want %q", got, want)
+	}
+}
+
+func TestRankedJobsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	jobs := []dtos.RankedJob{{
+		Job:           dtos.Job{Title: "=cmd|' /C calc'!A0", Company: "Acme, Inc.", URL: "https://jobs.example/1"},
+		PercentMatch:  87.5,
+		SkillsMatched: []string{"Go", "Kubernetes"},
+	}}
+	if err := RankedJobsCSV(&buf, jobs); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || !slices.Equal(records[0], jobColumns) {
+		t.Fatalf("records = %q", records)
+	}
+	want := []string{"'=cmd|' /C calc'!A0", "Acme, Inc.", "", "https://jobs.example/1", "", "87.5", "Go; Kubernetes", ""}
+	if !slices.Equal(records[1], want) {
+		t.Errorf("row = %q, want %q", records[1], want)
+	}
+}
@@ -0,0 +1,107 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+const icsLineLimit = 75
+
+// FollowUpCalendar writes an all-day reminder for every application. A
+// reminder falls on the application's follow-up date or, when none is set,
+// followUpAfter days after it was applied. Applications with neither date
+// are skipped.
+func FollowUpCalendar(w io.Writer, applications []dtos.Application, followUpAfter int, now time.Time) error {
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//TalentX//Job Assistance//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, application := range applications {
+		var due time.Time
+		switch {
+		case application.FollowUpAt != nil:
+			due = *application.FollowUpAt
+		case application.AppliedAt != nil:
+			due = application.AppliedAt.AddDate(0, 0, followUpAfter)
+		default:
+			continue
+		}
+
+		job := application.Job.Job
+		summary := "Follow up: " + job.Title
+		if job.Company != "" {
+			summary += " at " + job.Company
+		}
+
+		description := fmt.Sprintf("Status: %s", application.Status)
+		if application.AppliedAt != nil {
+			description += "\nApplied: " + application.AppliedAt.Format("2006-01-02")
+		}
+		if application.Notes != "" {
+			description += "\n\n" + application.Notes
+		}
+
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+application.ID+"@talentx")
+		writeLine(&b, "DTSTAMP:"+stamp)
+		writeLine(&b, "DTSTART;VALUE=DATE:"+due.Format("20060102"))
+		writeLine(&b, "DTEND;VALUE=DATE:"+due.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(&b, "SUMMARY:"+escapeText(summary))
+		writeLine(&b, "DESCRIPTION:"+escapeText(description))
+		if job.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(job.Location))
+		}
+		// URL values are not escaped, so one with a line break could add
+		// properties or events of its own; such URLs are left out.
+		if job.URL != "" && !strings.ContainsFunc(job.URL, unicode.IsControl) {
+			writeLine(&b, "URL:"+job.URL)
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// writeLine folds content lines longer than 75 octets as required by
+// RFC 5545, without splitting UTF-8 sequences.
+func writeLine(b *strings.Builder, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icsLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
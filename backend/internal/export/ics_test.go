@@ -0,0 +1,106 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain", "plain"},
+		{`a\b`, `a\\b`},
+		{"a;b,c", `a\;b\,c`},
+		{"one\r\ntwo\nthree\rfour", `one\ntwo\nthree\nfour`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteLineFoldsMultiByteText(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("日本語のテキスト", 10)
+
+	var b strings.Builder
+	writeLine(&b, line)
+	out := b.String()
+
+	if !strings.HasSuffix(out, "\r\n") {
+		t.Fatalf("line does not end in CRLF: %q", out)
+	}
+	physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+	if len(physical) < 2 {
+		t.Fatalf("expected the line to be folded, got %q", out)
+	}
+
+	var unfolded strings.Builder
+	for i, part := range physical {
+		if len(part) > icsLineLimit {
+			t.Errorf("line %d is %d octets, want at most %d", i, len(part), icsLineLimit)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(part, " ") {
+				t.Fatalf("continuation line %d does not start with a space: %q", i, part)
+			}
+			part = part[1:]
+		}
+		if !utf8.ValidString(part) {
+			t.Errorf("line %d splits a UTF-8 sequence: %q", i, part)
+		}
+		unfolded.WriteString(part)
+	}
+	if unfolded.String() != line {
+		t.Errorf("unfolded line = %q, want %q", unfolded.String(), line)
+	}
+}
+
+func TestFollowUpCalendar(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	applied := time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)
+	followUp := time.Date(2025, 2, 20, 0, 0, 0, 0, time.UTC)
+
+	applications := []dtos.Application{
+		{ID: "explicit", Status: "applied", AppliedAt: &applied, FollowUpAt: &followUp, Job: dtos.RankedJob{Job: dtos.Job{Title: "Go Developer", URL: "https://jobs.example/1"}}},
+		{ID: "fallback", Status: "applied", AppliedAt: &applied, Job: dtos.RankedJob{Job: dtos.Job{Title: "SRE", Company: "Acme"}}},
+		{ID: "undated", Status: "saved", Job: dtos.RankedJob{Job: dtos.Job{Title: "Skipped"}}},
+		{ID: "injected", Status: "applied", FollowUpAt: &followUp, Job: dtos.RankedJob{Job: dtos.Job{
+			Title: "Injected",
+			URL:   "https://jobs.example/2\r\nEND:VEVENT\r\nBEGIN:VEVENT\r\nSUMMARY:pwned",
+		}}},
+	}
+
+	var buf bytes.Buffer
+	if err := FollowUpCalendar(&buf, applications, 7, now); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"UID:explicit@talentx\r\nDTSTAMP:20250301T120000Z\r\nDTSTART;VALUE=DATE:20250220\r\nDTEND;VALUE=DATE:20250221\r\n",
+		"UID:fallback@talentx\r\nDTSTAMP:20250301T120000Z\r\nDTSTART;VALUE=DATE:20250217\r\n",
+		"SUMMARY:Follow up: SRE at Acme\r\n",
+		"URL:https://jobs.example/1\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar is missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "undated") {
+		t.Errorf("application without dates should be skipped:\n%s", out)
+	}
+	if got := strings.Count(out, "BEGIN:VEVENT"); got != 3 {
+		t.Errorf("calendar has %d events, want 3", got)
+	}
+	if strings.Contains(out, "pwned") || strings.Contains(out, "jobs.example/2") {
+		t.Errorf("URL with line breaks was written:\n%s", out)
+	}
+}
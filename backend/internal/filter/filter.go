@@ -0,0 +1,228 @@
+package filter
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/salary"
+	"github.com/lakshya1goel/job-assistance/internal/skills"
+)
+
+const (
+	RuleMaxAge             = "max_age_days"
+	RuleMinSalary          = "min_salary"
+	RuleExcludeCompany     = "exclude_companies"
+	RuleExcludeTitle       = "exclude_titles"
+	RuleExcludeDescription = "exclude_keywords"
+	RuleExcludeDomain      = "exclude_domains"
+	RuleRequiredSkills     = "required_skills"
+	RuleEmploymentType     = "employment_types"
+)
+
+var companySuffixes = []string{"inc", "llc", "ltd", "limited", "corp", "corporation", "co", "gmbh", "plc", "pvt"}
+
+type rule struct {
+	name    string
+	enabled bool
+	reject  func(job dtos.Job) bool
+}
+
+// Apply drops jobs that fail any enabled rule and reports how many jobs each
+// rule removed. Exclusions are checked before inclusion requirements and a
+// job is attributed to the first rule it fails. Jobs that do not state the
+// information a rule needs are kept.
+func Apply(jobs []dtos.Job, filters dtos.JobFilters, now time.Time, fxRates map[string]float64) ([]dtos.Job, map[string]int) {
+	excludedCompanies := make(map[string]bool, len(filters.ExcludeCompanies))
+	for _, company := range filters.ExcludeCompanies {
+		excludedCompanies[normalizeCompany(company)] = true
+	}
+	delete(excludedCompanies, "")
+
+	employmentTypes := make([]string, 0, len(filters.EmploymentTypes))
+	for _, employmentType := range filters.EmploymentTypes {
+		employmentTypes = append(employmentTypes, normalizeEmploymentType(employmentType))
+	}
+
+	taxonomy := skills.Default()
+	requiredSkills := taxonomy.Normalize(filters.RequiredSkills)
+
+	rules := []rule{
+		{
+			name:    RuleExcludeCompany,
+			enabled: len(excludedCompanies) > 0,
+			reject: func(job dtos.Job) bool {
+				return excludedCompanies[normalizeCompany(job.Company)]
+			},
+		},
+		{
+			name:    RuleExcludeTitle,
+			enabled: len(filters.ExcludeTitleKeywords) > 0,
+			reject: func(job dtos.Job) bool {
+				return containsAny(job.Title, filters.ExcludeTitleKeywords)
+			},
+		},
+		{
+			name:    RuleExcludeDescription,
+			enabled: len(filters.ExcludeKeywords) > 0,
+			reject: func(job dtos.Job) bool {
+				return containsAny(job.Description, filters.ExcludeKeywords)
+			},
+		},
+		{
+			name:    RuleExcludeDomain,
+			enabled: len(filters.ExcludeDomains) > 0,
+			reject: func(job dtos.Job) bool {
+				return matchesDomain(job.URL, filters.ExcludeDomains)
+			},
+		},
+		{
+			name:    RuleMaxAge,
+			enabled: filters.MaxAgeDays > 0,
+			reject: func(job dtos.Job) bool {
+				return job.PostedAt != nil && now.Sub(*job.PostedAt) > time.Duration(filters.MaxAgeDays)*24*time.Hour
+			},
+		},
+		{
+			name:    RuleMinSalary,
+			enabled: filters.MinSalary > 0,
+			reject: func(job dtos.Job) bool {
+				upper, ok := salary.AnnualUpperBound(job.Salary, filters.Currency, fxRates)
+				return ok && upper < filters.MinSalary
+			},
+		},
+		{
+			name:    RuleEmploymentType,
+			enabled: len(employmentTypes) > 0,
+			reject: func(job dtos.Job) bool {
+				return job.EmploymentType != "" && !slices.Contains(employmentTypes, normalizeEmploymentType(job.EmploymentType))
+			},
+		},
+		{
+			name:    RuleRequiredSkills,
+			enabled: len(requiredSkills) > 0,
+			reject: func(job dtos.Job) bool {
+				if len(job.RequiredSkills) == 0 && job.Description == "" {
+					return false
+				}
+				jobSkills := taxonomy.Normalize(job.RequiredSkills)
+				for _, skill := range requiredSkills {
+					if !slices.Contains(jobSkills, skill) && !taxonomy.Contains(job.Title+"\n"+job.Description, skill) {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+
+	counts := make(map[string]int)
+	kept := make([]dtos.Job, 0, len(jobs))
+	for _, job := range jobs {
+		rejected := false
+		for _, rule := range rules {
+			if rule.enabled && rule.reject(job) {
+				counts[rule.name]++
+				rejected = true
+				break
+			}
+		}
+		if !rejected {
+			kept = append(kept, job)
+		}
+	}
+
+	return kept, counts
+}
+
+func AgeInDays(job dtos.Job, now time.Time) (int, bool) {
+	if job.PostedAt == nil {
+		return 0, false
+	}
+	return max(0, int(now.Sub(*job.PostedAt).Hours()/24)), true
+}
+
+func normalizeCompany(company string) string {
+	words := strings.FieldsFunc(strings.ToLower(company), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&'
+	})
+	for len(words) > 1 && slices.Contains(companySuffixes, words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// normalizeEmploymentType maps the spellings used by the different sources
+// ("Full-time", "FULLTIME", "full_time") onto the JSearch constants.
+func normalizeEmploymentType(employmentType string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, employmentType)
+}
+
+func containsAny(text string, keywords []string) bool {
+	text = strings.ToLower(text)
+	for _, keyword := range keywords {
+		if containsPhrase(text, strings.ToLower(strings.TrimSpace(keyword))) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPhrase(text, phrase string) bool {
+	if phrase == "" {
+		return false
+	}
+	for offset := 0; ; {
+		index := strings.Index(text[offset:], phrase)
+		if index == -1 {
+			return false
+		}
+		start := offset + index
+		if isWordBoundary(text, start, start+len(phrase)) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+// isWordBoundary reports whether text[start:end] is not directly preceded or
+// followed by a letter or digit.
+func isWordBoundary(text string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(before) {
+		return false
+	}
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return !isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func matchesDomain(rawURL string, domains []string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host == "" {
+		return false
+	}
+
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "www.")
+		if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
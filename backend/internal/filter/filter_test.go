@@ -0,0 +1,167 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func TestContainsPhrase(t *testing.T) {
+	tests := []struct {
+		text   string
+		phrase string
+		want   bool
+	}{
+		{"senior go developer", "go", true},
+		{"google cloud", "go", false},
+		{"go—kubernetes", "go", true},
+		{"«go»", "go", true},
+		{"cafégo", "go", false},
+		{"goé", "go", false},
+		{"talent acquisition", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := containsPhrase(tt.text, tt.phrase); got != tt.want {
+			t.Errorf("containsPhrase(%q, %q) = %v, want %v", tt.text, tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *time.Time {
+		postedAt := now.AddDate(0, 0, -days)
+		return &postedAt
+	}
+	annual := func(amount float64, currency string) *dtos.Salary {
+		return &dtos.Salary{Currency: currency, AnnualMax: &amount}
+	}
+	fxRates := map[string]float64{"USD": 1, "EUR": 0.5}
+
+	tests := []struct {
+		name       string
+		jobs       []dtos.Job
+		filters    dtos.JobFilters
+		wantTitles []string
+		wantCounts map[string]int
+	}{
+		{
+			name:       "no filters",
+			jobs:       []dtos.Job{{Title: "Go Developer"}, {Title: "Recruiter"}},
+			wantTitles: []string{"Go Developer", "Recruiter"},
+			wantCounts: map[string]int{},
+		},
+		{
+			name: "excluded companies ignore case and suffixes",
+			jobs: []dtos.Job{
+				{Title: "a", Company: "Acme Inc."},
+				{Title: "b", Company: "ACME"},
+				{Title: "c", Company: "Acme Robotics"},
+			},
+			filters:    dtos.JobFilters{ExcludeCompanies: []string{"acme corp", " "}},
+			wantTitles: []string{"c"},
+			wantCounts: map[string]int{RuleExcludeCompany: 2},
+		},
+		{
+			name: "excluded titles, keywords and domains",
+			jobs: []dtos.Job{
+				{Title: "Senior Go Developer", URL: "https://jobs.example/1"},
+				{Title: "Go Developer Intern", URL: "https://jobs.example/2"},
+				{Title: "Go Developer", Description: "Unpaid role", URL: "https://jobs.example/3"},
+				{Title: "Go Developer", URL: "https://careers.spam.example/4"},
+				{Title: "Go Developer", URL: "https://notspam.example/5"},
+			},
+			filters: dtos.JobFilters{
+				ExcludeTitleKeywords: []string{"intern"},
+				ExcludeKeywords:      []string{"unpaid"},
+				ExcludeDomains:       []string{"spam.example"},
+			},
+			wantTitles: []string{"Senior Go Developer", "Go Developer"},
+			wantCounts: map[string]int{RuleExcludeTitle: 1, RuleExcludeDescription: 1, RuleExcludeDomain: 1},
+		},
+		{
+			name: "max age keeps undated jobs",
+			jobs: []dtos.Job{
+				{Title: "fresh", PostedAt: daysAgo(3)},
+				{Title: "stale", PostedAt: daysAgo(30)},
+				{Title: "undated"},
+			},
+			filters:    dtos.JobFilters{MaxAgeDays: 14},
+			wantTitles: []string{"fresh", "undated"},
+			wantCounts: map[string]int{RuleMaxAge: 1},
+		},
+		{
+			name: "min salary converts currencies and keeps unknown salaries",
+			jobs: []dtos.Job{
+				{Title: "well paid", Salary: annual(120000, "USD")},
+				{Title: "underpaid", Salary: annual(80000, "USD")},
+				{Title: "converted", Salary: annual(60000, "EUR")},
+				{Title: "unknown currency", Salary: annual(1000, "XYZ")},
+				{Title: "no salary"},
+			},
+			filters:    dtos.JobFilters{MinSalary: 100000, Currency: "USD"},
+			wantTitles: []string{"well paid", "converted", "unknown currency", "no salary"},
+			wantCounts: map[string]int{RuleMinSalary: 1},
+		},
+		{
+			name: "employment types match any spelling",
+			jobs: []dtos.Job{
+				{Title: "full time", EmploymentType: "FULLTIME"},
+				{Title: "contract", EmploymentType: "Contractor"},
+				{Title: "unstated"},
+			},
+			filters:    dtos.JobFilters{EmploymentTypes: []string{"full-time"}},
+			wantTitles: []string{"full time", "unstated"},
+			wantCounts: map[string]int{RuleEmploymentType: 1},
+		},
+		{
+			name: "required skills from skill lists or descriptions",
+			jobs: []dtos.Job{
+				{Title: "listed", RequiredSkills: []string{"golang", "kubernetes"}},
+				{Title: "described", Description: "Go services on Kubernetes"},
+				{Title: "partial", Description: "Go services on bare metal"},
+				{Title: "no details"},
+			},
+			filters:    dtos.JobFilters{RequiredSkills: []string{"Go", "Kubernetes"}},
+			wantTitles: []string{"listed", "described", "no details"},
+			wantCounts: map[string]int{RuleRequiredSkills: 1},
+		},
+		{
+			name: "first failing rule takes the job",
+			jobs: []dtos.Job{
+				{Title: "Go Intern", Company: "Acme", PostedAt: daysAgo(30)},
+				{Title: "Java Intern", Description: "Java only", PostedAt: daysAgo(30)},
+				{Title: "Java Developer", Description: "Java only", PostedAt: daysAgo(30)},
+				{Title: "Java Developer", Description: "Java only", PostedAt: daysAgo(1)},
+			},
+			filters: dtos.JobFilters{
+				ExcludeCompanies:     []string{"Acme"},
+				ExcludeTitleKeywords: []string{"intern"},
+				MaxAgeDays:           14,
+				RequiredSkills:       []string{"Go"},
+			},
+			wantTitles: []string{},
+			wantCounts: map[string]int{RuleExcludeCompany: 1, RuleExcludeTitle: 1, RuleMaxAge: 1, RuleRequiredSkills: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, counts := Apply(tt.jobs, tt.filters, now, fxRates)
+
+			titles := make([]string, 0, len(kept))
+			for _, job := range kept {
+				titles = append(titles, job.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.wantTitles) {
+				t.Errorf("kept %q, want %q", titles, tt.wantTitles)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("counts = %v, want %v", counts, tt.wantCounts)
+			}
+		})
+	}
+}
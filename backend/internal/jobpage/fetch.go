@@ -0,0 +1,112 @@
+// Package jobpage fetches job posting pages and extracts the posting from
+// their schema.org JSON-LD or, failing that, from the HTML itself.
+package jobpage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/netguard"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+	"golang.org/x/net/html/charset"
+)
+
+const maxRedirects = 5
+
+type Options struct {
+	Timeout   time.Duration
+	MaxBytes  int64
+	UserAgent string
+	// AllowPrivate permits fetching loopback and private network addresses.
+	AllowPrivate bool
+}
+
+type Fetcher struct {
+	Client    *http.Client
+	MaxBytes  int64
+	UserAgent string
+}
+
+type Page struct {
+	URL         string
+	ContentType string
+	Body        string
+}
+
+func NewFetcher(options Options) *Fetcher {
+	if options.Timeout <= 0 {
+		options.Timeout = 15 * time.Second
+	}
+	if options.MaxBytes <= 0 {
+		options.MaxBytes = 2 << 20
+	}
+
+	// The URL comes from the user, so private addresses are refused unless
+	// explicitly allowed.
+	transport := netguard.NewTransport(options.AllowPrivate)
+
+	return &Fetcher{
+		Client: &http.Client{
+			Transport: tracing.Transport(transport),
+			Timeout:   options.Timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+				}
+				return nil
+			},
+		},
+		MaxBytes:  options.MaxBytes,
+		UserAgent: options.UserAgent,
+	}
+}
+
+// Fetch downloads the page and returns its body decoded to UTF-8.
+func (f *Fetcher) Fetch(ctx context.Context, pageURL string) (*Page, error) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid job URL %q", pageURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.5")
+	if f.UserAgent != "" {
+		req.Header.Set("User-Agent", f.UserAgent)
+	}
+
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("job page returned status %d", resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	reader, err := charset.NewReader(io.LimitReader(resp.Body, f.MaxBytes), contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode job page: %w", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job page: %w", err)
+	}
+
+	return &Page{
+		URL:         resp.Request.URL.String(),
+		ContentType: contentType,
+		Body:        string(body),
+	}, nil
+}
@@ -0,0 +1,373 @@
+package jobpage
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/salary"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	MethodJSONLD = "json_ld"
+	MethodHTML   = "html"
+	MethodLLM    = "llm"
+	MethodText   = "text"
+)
+
+// minDescriptionChars is how much description the HTML heuristics must find
+// before their result is trusted over LLM extraction.
+const minDescriptionChars = 200
+
+// Extraction is the posting found in a page. Complete is false when only
+// weak HTML heuristics matched; Text then holds the page's visible text so
+// callers can fall back to another extractor.
+type Extraction struct {
+	Job      dtos.Job
+	Method   string
+	Complete bool
+	Text     string
+}
+
+func Extract(pageURL, body string) (*Extraction, error) {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	text := visibleText(doc)
+	if job, ok := jobFromJSONLD(doc); ok {
+		job.URL = pageURL
+		// Some pages only put the title in JSON-LD; the description then has
+		// to come from the page text.
+		return &Extraction{Job: job, Method: MethodJSONLD, Complete: job.Description != "", Text: text}, nil
+	}
+
+	job := jobFromHTML(doc)
+	job.URL = pageURL
+	return &Extraction{
+		Job:      job,
+		Method:   MethodHTML,
+		Complete: job.Title != "" && len(job.Description) >= minDescriptionChars,
+		Text:     text,
+	}, nil
+}
+
+func jobFromJSONLD(doc *html.Node) (dtos.Job, bool) {
+	for _, script := range findAll(doc, func(n *html.Node) bool {
+		return n.DataAtom == atom.Script && strings.EqualFold(strings.TrimSpace(attr(n, "type")), "application/ld+json")
+	}) {
+		var data any
+		if err := json.Unmarshal([]byte(textContent(script)), &data); err != nil {
+			continue
+		}
+		if posting := findJobPosting(data); posting != nil {
+			job := jobFromPosting(posting)
+			if job.Title != "" {
+				return job, true
+			}
+		}
+	}
+	return dtos.Job{}, false
+}
+
+// findJobPosting walks JSON-LD objects, arrays and @graph containers for the
+// first node typed JobPosting.
+func findJobPosting(data any) map[string]any {
+	switch value := data.(type) {
+	case []any:
+		for _, item := range value {
+			if posting := findJobPosting(item); posting != nil {
+				return posting
+			}
+		}
+	case map[string]any:
+		if hasType(value["@type"], "JobPosting") {
+			return value
+		}
+		if graph, ok := value["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	}
+	return nil
+}
+
+func hasType(value any, want string) bool {
+	switch typed := value.(type) {
+	case string:
+		return strings.EqualFold(typed, want)
+	case []any:
+		for _, item := range typed {
+			if hasType(item, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jobFromPosting(posting map[string]any) dtos.Job {
+	job := dtos.Job{
+		Title:          cleanText(stringValue(posting["title"])),
+		Description:    htmlToText(stringValue(posting["description"])),
+		EmploymentType: employmentType(posting["employmentType"]),
+	}
+
+	if organization, ok := first(posting["hiringOrganization"]).(map[string]any); ok {
+		job.Company = cleanText(stringValue(organization["name"]))
+	} else {
+		job.Company = cleanText(stringValue(posting["hiringOrganization"]))
+	}
+
+	if location, ok := first(posting["jobLocation"]).(map[string]any); ok {
+		address, _ := location["address"].(map[string]any)
+		if address != nil {
+			locality := stringValue(address["addressLocality"])
+			job.State = stringValue(address["addressRegion"])
+			job.Country = stringValue(address["addressCountry"])
+			if country, ok := address["addressCountry"].(map[string]any); ok {
+				job.Country = stringValue(country["name"])
+			}
+			job.Location = joinNonEmpty(", ", locality, job.State, job.Country)
+		}
+	}
+	if strings.EqualFold(stringValue(posting["jobLocationType"]), "TELECOMMUTE") {
+		job.Location = joinNonEmpty(" / ", "Remote", job.Location)
+	}
+
+	if posted := stringValue(posting["datePosted"]); posted != "" {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+			if parsed, err := time.Parse(layout, posted); err == nil {
+				job.PostedAt = &parsed
+				break
+			}
+		}
+	}
+
+	if baseSalary, ok := posting["baseSalary"].(map[string]any); ok {
+		job.Salary = salaryFromPosting(baseSalary)
+	}
+	return job
+}
+
+func salaryFromPosting(baseSalary map[string]any) *dtos.Salary {
+	result := &dtos.Salary{Currency: stringValue(baseSalary["currency"])}
+	value := baseSalary["value"]
+	if quantity, ok := value.(map[string]any); ok {
+		result.Min = numberValue(quantity["minValue"])
+		result.Max = numberValue(quantity["maxValue"])
+		if result.Min == nil && result.Max == nil {
+			result.Min = numberValue(quantity["value"])
+			result.Max = result.Min
+		}
+		result.Period = stringValue(quantity["unitText"])
+	} else {
+		result.Min = numberValue(value)
+		result.Max = result.Min
+	}
+	return salary.Normalize(result)
+}
+
+// employmentType maps schema.org values such as FULL_TIME onto the
+// FULLTIME/PARTTIME/CONTRACTOR/INTERN values used elsewhere.
+func employmentType(value any) string {
+	raw := strings.ToUpper(strings.NewReplacer("_", "", "-", "", " ", "").Replace(stringValue(first(value))))
+	switch raw {
+	case "FULLTIME", "PARTTIME", "INTERN":
+		return raw
+	case "CONTRACTOR", "CONTRACT", "TEMPORARY":
+		return "CONTRACTOR"
+	case "INTERNSHIP":
+		return "INTERN"
+	}
+	return ""
+}
+
+func jobFromHTML(doc *html.Node) dtos.Job {
+	job := dtos.Job{
+		Title:   metaContent(doc, "og:title"),
+		Company: metaContent(doc, "og:site_name"),
+	}
+	if job.Title == "" {
+		if h1 := findFirst(doc, func(n *html.Node) bool { return n.DataAtom == atom.H1 }); h1 != nil {
+			job.Title = cleanText(textContent(h1))
+		}
+	}
+	if job.Title == "" {
+		if title := findFirst(doc, func(n *html.Node) bool { return n.DataAtom == atom.Title }); title != nil {
+			job.Title = cleanText(textContent(title))
+		}
+	}
+
+	content := findFirst(doc, func(n *html.Node) bool { return n.DataAtom == atom.Main || n.DataAtom == atom.Article })
+	if content == nil {
+		content = doc
+	}
+	job.Description = visibleText(content)
+	return job
+}
+
+var skippedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Nav: true, atom.Header: true, atom.Footer: true, atom.Svg: true, atom.Form: true,
+}
+
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Li: true, atom.Br: true, atom.Section: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Tr: true, atom.Ul: true, atom.Ol: true, atom.Article: true, atom.Main: true,
+}
+
+// visibleText renders the node's text with one line per block element,
+// skipping scripts and page chrome.
+func visibleText(root *html.Node) string {
+	var builder strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && skippedElements[n.DataAtom] {
+			return
+		}
+		if n.Type == html.TextNode {
+			builder.WriteString(n.Data)
+		}
+		if n.Type == html.ElementNode && blockElements[n.DataAtom] {
+			builder.WriteString("\n")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if n.Type == html.ElementNode && blockElements[n.DataAtom] {
+			builder.WriteString("\n")
+		}
+	}
+	walk(root)
+
+	var lines []string
+	for _, line := range strings.Split(builder.String(), "\n") {
+		if line = cleanText(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// htmlToText handles JSON-LD descriptions, which are usually HTML fragments
+// but sometimes plain text.
+func htmlToText(fragment string) string {
+	if !strings.Contains(fragment, "<") {
+		return strings.TrimSpace(fragment)
+	}
+	doc, err := html.Parse(strings.NewReader(fragment))
+	if err != nil {
+		return strings.TrimSpace(fragment)
+	}
+	return visibleText(doc)
+}
+
+func metaContent(doc *html.Node, property string) string {
+	meta := findFirst(doc, func(n *html.Node) bool {
+		return n.DataAtom == atom.Meta && (attr(n, "property") == property || attr(n, "name") == property)
+	})
+	if meta == nil {
+		return ""
+	}
+	return cleanText(attr(meta, "content"))
+}
+
+func findFirst(root *html.Node, match func(*html.Node) bool) *html.Node {
+	if root.Type == html.ElementNode && match(root) {
+		return root
+	}
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		if found := findFirst(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func findAll(root *html.Node, match func(*html.Node) bool) []*html.Node {
+	var found []*html.Node
+	if root.Type == html.ElementNode && match(root) {
+		found = append(found, root)
+	}
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		found = append(found, findAll(child, match)...)
+	}
+	return found
+}
+
+func attr(n *html.Node, name string) string {
+	for _, attribute := range n.Attr {
+		if attribute.Key == name {
+			return attribute.Val
+		}
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	var builder strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			builder.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return builder.String()
+}
+
+func cleanText(text string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+}
+
+func joinNonEmpty(separator string, parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, separator)
+}
+
+func first(value any) any {
+	if list, ok := value.([]any); ok {
+		if len(list) == 0 {
+			return nil
+		}
+		return list[0]
+	}
+	return value
+}
+
+func stringValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	}
+	return ""
+}
+
+func numberValue(value any) *float64 {
+	switch typed := value.(type) {
+	case float64:
+		return &typed
+	case string:
+		parsed, err := strconv.ParseFloat(strings.ReplaceAll(typed, ",", ""), 64)
+		if err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
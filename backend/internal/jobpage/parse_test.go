@@ -0,0 +1,85 @@
+package jobpage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractJSONLD(t *testing.T) {
+	page := `<html><head><script type="application/ld+json">
+{"@context":"https://schema.org","@graph":[{"@type":"Organization","name":"Acme"},{"@type":["JobPosting"],
+"title":"Senior Go Engineer","description":"<p>Build &amp; run <b>Go</b> services.</p><ul><li>Kubernetes</li></ul>",
+"hiringOrganization":{"@type":"Organization","name":"Acme"},
+"jobLocation":[{"@type":"Place","address":{"addressLocality":"Berlin","addressRegion":"BE","addressCountry":{"name":"DE"}}}],
+"jobLocationType":"TELECOMMUTE","datePosted":"2026-10-01","employmentType":["FULL_TIME"],
+"baseSalary":{"currency":"eur","value":{"minValue":70000,"maxValue":"90,000","unitText":"YEAR"}}}]}
+</script></head><body></body></html>`
+
+	extraction, err := Extract("https://acme.example/jobs/1", page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := extraction.Job
+	if extraction.Method != MethodJSONLD || !extraction.Complete {
+		t.Fatalf("method = %q, complete = %v, want json_ld and complete", extraction.Method, extraction.Complete)
+	}
+	if job.Title != "Senior Go Engineer" || job.Company != "Acme" {
+		t.Errorf("title/company = %q/%q", job.Title, job.Company)
+	}
+	if job.Location != "Remote / Berlin, BE, DE" {
+		t.Errorf("location = %q", job.Location)
+	}
+	if job.Description != "Build & run Go services.\nKubernetes" {
+		t.Errorf("description = %q", job.Description)
+	}
+	if job.EmploymentType != "FULLTIME" {
+		t.Errorf("employment type = %q", job.EmploymentType)
+	}
+	if job.PostedAt == nil || job.PostedAt.Format("2006-01-02") != "2026-10-01" {
+		t.Errorf("posted at = %v", job.PostedAt)
+	}
+	if job.Salary == nil || *job.Salary.Min != 70000 || *job.Salary.Max != 90000 || job.Salary.Currency != "EUR" {
+		t.Errorf("salary = %+v", job.Salary)
+	}
+}
+
+func TestExtractJSONLDWithoutDescription(t *testing.T) {
+	page := `<html><head><script type="application/ld+json">{"@type":"JobPosting","title":"Backend Engineer"}</script></head>
+<body><main><p>We are hiring a backend engineer to work on Go services.</p></main></body></html>`
+
+	extraction, err := Extract("https://acme.example/jobs/2", page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Complete {
+		t.Error("a JSON-LD posting without a description must not be complete")
+	}
+	if extraction.Job.Title != "Backend Engineer" {
+		t.Errorf("title = %q", extraction.Job.Title)
+	}
+	if !strings.Contains(extraction.Text, "We are hiring a backend engineer") {
+		t.Errorf("text = %q, want the page text for the fallback", extraction.Text)
+	}
+}
+
+func TestExtractHTML(t *testing.T) {
+	page := `<html><head><title>Jobs | Data Analyst</title><meta property="og:site_name" content="Beta Corp"></head>
+<body><nav>Home About</nav><main><h1>Data Analyst</h1><p>We need SQL and Python.</p><script>var x = 1</script></main><footer>Footer</footer></body></html>`
+
+	extraction, err := Extract("https://beta.example/jobs/3", page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extraction.Method != MethodHTML {
+		t.Errorf("method = %q, want html", extraction.Method)
+	}
+	if extraction.Complete {
+		t.Error("a short HTML description must not be complete")
+	}
+	if extraction.Job.Title != "Data Analyst" || extraction.Job.Company != "Beta Corp" {
+		t.Errorf("title/company = %q/%q", extraction.Job.Title, extraction.Job.Company)
+	}
+	if extraction.Job.Description != "Data Analyst\nWe need SQL and Python." {
+		t.Errorf("description = %q", extraction.Job.Description)
+	}
+}
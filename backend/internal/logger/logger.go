@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
+var (
+	base   = slog.New(slog.NewTextHandler(os.Stdout, nil))
+	redact = true
+)
+
+type Options struct {
+	Format string
+	Level  string
+	Redact bool
+	Output io.Writer
+}
+
+func Init(opts Options) {
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: parseLevel(opts.Level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(opts.Format, "json") {
+		handler = slog.NewJSONHandler(output, handlerOptions)
+	} else {
+		handler = slog.NewTextHandler(output, handlerOptions)
+	}
+
+	base = slog.New(handler)
+	redact = opts.Redact
+	slog.SetDefault(base)
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+func FromContext(ctx context.Context) *slog.Logger {
+	if requestID := RequestID(ctx); requestID != "" {
+		return base.With("request_id", requestID)
+	}
+	return base
+}
+
+func Sensitive(value string) string {
+	if !redact {
+		return value
+	}
+	return fmt.Sprintf("[REDACTED %d chars]", len(value))
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "talentx"
+
+const (
+	StageProfileExtraction = "profile_extraction"
+	StageJobSearch         = "job_search"
+	StageRanking           = "ranking"
+	StageCoverLetter       = "cover_letter"
+	StageResumeTailoring   = "resume_tailoring"
+	StageInterviewPrep     = "interview_prep"
+	StageJobExtraction     = "job_extraction"
+	StageTotal             = "total"
+)
+
+const (
+	BatchParsed   = "parsed"
+	BatchPartial  = "partial"
+	BatchFallback = "fallback"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	stageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "pipeline_stage_duration_seconds",
+		Help:      "Duration of each stage of the job search pipeline.",
+		Buckets:   []float64{0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120},
+	}, []string{"stage", "status"})
+
+	sourceRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_source_requests_total",
+		Help:      "Number of search requests sent to each job source.",
+	}, []string{"source"})
+
+	sourceErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_source_errors_total",
+		Help:      "Number of failed search requests per job source.",
+	}, []string{"source"})
+
+	sourceJobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_source_jobs_total",
+		Help:      "Number of jobs returned by each job source.",
+	}, []string{"source"})
+
+	rankingBatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ranking_batches_total",
+		Help:      "Ranking batches by outcome (parsed, partial, fallback).",
+	}, []string{"outcome"})
+
+	llmTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "llm_tokens_total",
+		Help:      "Gemini tokens consumed, by model, operation and token type.",
+	}, []string{"model", "operation", "type"})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		stageDuration,
+		sourceRequests,
+		sourceErrors,
+		sourceJobs,
+		rankingBatches,
+		llmTokens,
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+func ObserveStage(stage string, start time.Time, err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	stageDuration.WithLabelValues(stage, status).Observe(time.Since(start).Seconds())
+}
+
+func RecordSourceResult(source string, jobs int, err error) {
+	sourceRequests.WithLabelValues(source).Inc()
+	if err != nil {
+		sourceErrors.WithLabelValues(source).Inc()
+		return
+	}
+	sourceJobs.WithLabelValues(source).Add(float64(jobs))
+}
+
+func RecordRankingBatch(outcome string) {
+	rankingBatches.WithLabelValues(outcome).Inc()
+}
+
+func RecordTokens(model, operation string, promptTokens, responseTokens, totalTokens int32) {
+	llmTokens.WithLabelValues(model, operation, "prompt").Add(float64(promptTokens))
+	llmTokens.WithLabelValues(model, operation, "response").Add(float64(responseTokens))
+	llmTokens.WithLabelValues(model, operation, "total").Add(float64(totalTokens))
+}
@@ -0,0 +1,47 @@
+// Package netguard builds HTTP transports for requests to user-supplied URLs
+// that refuse to connect to loopback, private and link-local addresses.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var ErrBlockedAddress = errors.New("address is not publicly routable")
+
+// NewTransport returns a clone of the default transport whose dialer rejects
+// non-public addresses unless allowPrivate is set. Proxies are disabled so
+// the check applies to the address actually connected to.
+func NewTransport(allowPrivate bool) *http.Transport {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivate {
+		dialer.Control = Control
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// Control is a net.Dialer Control function that runs after DNS resolution,
+// so hostnames resolving to blocked addresses are caught too.
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !Public(net.ParseIP(host)) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	return nil
+}
+
+func Public(ip net.IP) bool {
+	return ip != nil && !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
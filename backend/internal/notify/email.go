@@ -0,0 +1,153 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSMTPTimeout = 30 * time.Second
+
+type EmailSender struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	// Timeout bounds the whole SMTP conversation when ctx has no earlier
+	// deadline.
+	Timeout time.Duration
+}
+
+func NewEmailSender(host string, port int, username, password, from string) *EmailSender {
+	return &EmailSender{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+		Timeout:  defaultSMTPTimeout,
+	}
+}
+
+func (e *EmailSender) Channel() string {
+	return ChannelEmail
+}
+
+func (e *EmailSender) Send(ctx context.Context, target string, notification Notification) error {
+	to, err := mail.ParseAddress(target)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid recipient address: %w", err))
+	}
+	from, err := mail.ParseAddress(e.From)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid SMTP_FROM address: %w", err))
+	}
+
+	message := buildDigest(from, to, notification)
+	if err := e.send(ctx, from.Address, to.Address, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// send does what smtp.SendMail does, but on a connection with a deadline
+// that is also closed when ctx is cancelled, so a stalled server cannot
+// block the dispatcher.
+func (e *EmailSender) send(ctx context.Context, from, to string, message []byte) error {
+	timeout := e.Timeout
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(e.Host, strconv.Itoa(e.Port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, e.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: e.Host}); err != nil {
+			return err
+		}
+	}
+	if e.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.Username, e.Password, e.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func buildDigest(from, to *mail.Address, notification Notification) []byte {
+	var body strings.Builder
+	body.WriteString(summary(notification) + "\r\n\r\n")
+
+	for i, match := range topMatches(notification.Matches) {
+		fmt.Fprintf(&body, "%d. %s - %.0f%% match\r\n", i+1, describeJob(match.Job.Job), match.Job.PercentMatch)
+		if match.Job.MatchReason != "" {
+			fmt.Fprintf(&body, "   %s\r\n", singleLine(match.Job.MatchReason))
+		}
+		fmt.Fprintf(&body, "   %s\r\n\r\n", match.Job.Job.URL)
+	}
+	if extra := len(notification.Matches) - maxDigestJobs; extra > 0 {
+		fmt.Fprintf(&body, "...and %d more. View them all at /api/job/searches/%s/matches\r\n", extra, notification.Search.ID)
+	}
+
+	subject := fmt.Sprintf("New job matches for %s (%d)", singleLine(notification.Search.Name), len(notification.Matches))
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", from.String())
+	fmt.Fprintf(&message, "To: %s\r\n", to.String())
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(body.String())
+	return []byte(message.String())
+}
+
+// singleLine strips line breaks so user-provided text cannot inject headers.
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
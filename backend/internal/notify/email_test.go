@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"bufio"
+	"mime"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpSink is a minimal SMTP server that accepts a single message.
+type smtpSink struct {
+	listener net.Listener
+	messages chan string
+}
+
+func newSMTPSink(t *testing.T, greet bool) *smtpSink {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &smtpSink{listener: listener, messages: make(chan string, 1)}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if !greet {
+			// Stall: accept the connection and never answer.
+			time.Sleep(5 * time.Second)
+			return
+		}
+		sink.serve(conn)
+	}()
+	return sink
+}
+
+func (s *smtpSink) serve(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 sink ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 sink")
+		case strings.HasPrefix(command, "DATA"):
+			reply("354 go ahead")
+			var message strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				message.WriteString(dataLine)
+			}
+			s.messages <- message.String()
+			reply("250 queued")
+		case strings.HasPrefix(command, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *smtpSink) sender() *EmailSender {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return NewEmailSender(host, portNumber, "", "", "TalentX <alerts@talentx.example>")
+}
+
+func TestEmailDigest(t *testing.T) {
+	sink := newSMTPSink(t, true)
+
+	notification := testNotification("Go jobs\r\nBcc: victim@evil.example", "me@example.com", "")
+	if err := sink.sender().Send(t.Context(), "me@example.com", notification); err != nil {
+		t.Fatal(err)
+	}
+
+	var raw string
+	select {
+	case raw = <-sink.messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := message.Header.Get("To"); got != "<me@example.com>" {
+		t.Errorf("To = %q", got)
+	}
+	if got := message.Header.Get("From"); !strings.Contains(got, "alerts@talentx.example") {
+		t.Errorf("From = %q", got)
+	}
+	if got := message.Header.Get("Content-Type"); got != "text/plain; charset=UTF-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "New job matches for Go jobs Bcc: victim@evil.example (2)" {
+		t.Errorf("Subject = %q", subject)
+	}
+	if len(message.Header["Bcc"]) != 0 {
+		t.Errorf("header injection added Bcc: %v", message.Header["Bcc"])
+	}
+
+	headerEnd := strings.Index(raw, "\r\n\r\n")
+	for _, line := range strings.Split(raw[:headerEnd], "\r\n") {
+		if strings.HasPrefix(strings.ToLower(line), "bcc:") {
+			t.Errorf("injected header line %q", line)
+		}
+	}
+	body := raw[headerEnd:]
+	if !strings.Contains(body, "1. Go Engineer at Acme - 91% match") || !strings.Contains(body, "https://acme.example/jobs/2") {
+		t.Errorf("body = %q", body)
+	}
+	if strings.Contains(body, "Go\r\nBcc:") {
+		t.Errorf("match reason kept its line break: %q", body)
+	}
+}
+
+func TestEmailTimesOutOnStalledServer(t *testing.T) {
+	sink := newSMTPSink(t, false)
+	sender := sink.sender()
+	sender.Timeout = 200 * time.Millisecond
+
+	start := time.Now()
+	err := sender.Send(t.Context(), "me@example.com", testNotification("Go jobs", "me@example.com", ""))
+	if err == nil {
+		t.Fatal("expected an error from a stalled server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("send took %v, want it bounded by the timeout", elapsed)
+	}
+}
+
+func TestSingleLine(t *testing.T) {
+	if got := singleLine("a\r\nBcc: x@y.example\n\tb"); got != "a Bcc: x@y.example b" {
+		t.Errorf("singleLine = %q", got)
+	}
+}
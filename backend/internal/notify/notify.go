@@ -0,0 +1,228 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+)
+
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+
+	maxDigestJobs = 10
+)
+
+type Notification struct {
+	Search  dtos.SavedSearch
+	RunID   string
+	Matches []dtos.SavedSearchMatch
+}
+
+// Sender delivers a notification to a single target. Errors wrapped with
+// Permanent are not retried.
+type Sender interface {
+	Channel() string
+	Send(ctx context.Context, target string, notification Notification) error
+}
+
+type DeliveryLog interface {
+	SaveDelivery(ctx context.Context, delivery *dtos.NotificationDelivery) error
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+type Dispatcher struct {
+	senders     map[string]Sender
+	log         DeliveryLog
+	maxAttempts int
+	backoff     time.Duration
+
+	wg sync.WaitGroup
+}
+
+func NewDispatcher(log DeliveryLog, maxAttempts int, backoff time.Duration, senders ...Sender) *Dispatcher {
+	dispatcher := &Dispatcher{
+		senders:     make(map[string]Sender, len(senders)),
+		log:         log,
+		maxAttempts: max(1, maxAttempts),
+		backoff:     backoff,
+	}
+	for _, sender := range senders {
+		dispatcher.senders[sender.Channel()] = sender
+	}
+	return dispatcher
+}
+
+func (d *Dispatcher) Supports(channel string) bool {
+	_, ok := d.senders[channel]
+	return ok
+}
+
+// Dispatch delivers the notification to every channel configured on the
+// saved search and records one delivery log entry per channel.
+func (d *Dispatcher) Dispatch(ctx context.Context, notification Notification) []dtos.NotificationDelivery {
+	if len(notification.Matches) == 0 {
+		return nil
+	}
+
+	targets := map[string]string{
+		ChannelEmail:   notification.Search.NotifyEmail,
+		ChannelWebhook: notification.Search.WebhookURL,
+	}
+
+	var deliveries []dtos.NotificationDelivery
+	for _, channel := range []string{ChannelEmail, ChannelWebhook} {
+		target := targets[channel]
+		if target == "" {
+			continue
+		}
+		deliveries = append(deliveries, d.deliver(ctx, channel, target, notification))
+	}
+	return deliveries
+}
+
+// DispatchAsync runs Dispatch in the background. Wait blocks until every
+// background dispatch, including its retries, has finished.
+func (d *Dispatcher) DispatchAsync(ctx context.Context, notification Notification) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.Dispatch(ctx, notification)
+	}()
+}
+
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, channel, target string, notification Notification) dtos.NotificationDelivery {
+	log := logger.FromContext(ctx)
+	delivery := dtos.NotificationDelivery{
+		SearchID:  notification.Search.ID,
+		RunID:     notification.RunID,
+		Channel:   channel,
+		Target:    displayTarget(channel, target),
+		Matches:   len(notification.Matches),
+		CreatedAt: time.Now(),
+	}
+
+	sender, ok := d.senders[channel]
+	var err error
+	if !ok {
+		err = fmt.Errorf("%s notifications are not configured", channel)
+	}
+
+	for attempt := 1; ok && attempt <= d.maxAttempts; attempt++ {
+		if attempt > 1 {
+			if err = sleep(ctx, d.backoff<<(attempt-2)); err != nil {
+				break
+			}
+		}
+
+		delivery.Attempts = attempt
+		err = sender.Send(ctx, target, notification)
+		if err == nil {
+			break
+		}
+
+		log.Warn("notification attempt failed",
+			"channel", channel,
+			"search_id", notification.Search.ID,
+			"attempt", attempt,
+			"error", err,
+		)
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			break
+		}
+	}
+
+	delivery.CompletedAt = time.Now()
+	delivery.Status = StatusDelivered
+	if err != nil {
+		delivery.Status = StatusFailed
+		delivery.Error = err.Error()
+		log.Error("notification delivery failed", "channel", channel, "search_id", notification.Search.ID, "error", err)
+	}
+
+	if d.log != nil {
+		if err := d.log.SaveDelivery(ctx, &delivery); err != nil {
+			log.Warn("failed to record notification delivery", "error", err)
+		}
+	}
+	return delivery
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func topMatches(matches []dtos.SavedSearchMatch) []dtos.SavedSearchMatch {
+	sorted := make([]dtos.SavedSearchMatch, len(matches))
+	copy(sorted, matches)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Job.PercentMatch > sorted[j].Job.PercentMatch
+	})
+	return sorted[:min(maxDigestJobs, len(sorted))]
+}
+
+func summary(notification Notification) string {
+	noun := "jobs"
+	if len(notification.Matches) == 1 {
+		noun = "job"
+	}
+	return fmt.Sprintf("%d new %s matched your saved search %q.", len(notification.Matches), noun, notification.Search.Name)
+}
+
+func describeJob(job dtos.Job) string {
+	description := job.Title
+	if job.Company != "" {
+		description += " at " + job.Company
+	}
+	if job.Location != "" {
+		description += " (" + job.Location + ")"
+	}
+	return description
+}
+
+// displayTarget keeps webhook URLs out of the delivery log since chat
+// integrations embed their credentials in the path.
+func displayTarget(channel, target string) string {
+	if channel != ChannelWebhook {
+		return target
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
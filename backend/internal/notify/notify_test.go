@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+func testNotification(name, email, webhookURL string) Notification {
+	return Notification{
+		Search: dtos.SavedSearch{
+			ID:            "search-1",
+			Name:          name,
+			NotifyEmail:   email,
+			WebhookURL:    webhookURL,
+			WebhookSecret: "secret",
+		},
+		RunID: "run-1",
+		Matches: []dtos.SavedSearchMatch{
+			{Job: dtos.RankedJob{
+				Job:          dtos.Job{Title: "Go Engineer", Company: "Acme", URL: "https://acme.example/jobs/1"},
+				PercentMatch: 91,
+				MatchReason:  "Strong Go\r\nBcc: reason@evil.example",
+			}},
+			{Job: dtos.RankedJob{
+				Job:          dtos.Job{Title: "Platform Engineer", URL: "https://acme.example/jobs/2"},
+				PercentMatch: 75,
+			}},
+		},
+	}
+}
+
+type recordingLog struct {
+	mu         sync.Mutex
+	deliveries []dtos.NotificationDelivery
+}
+
+func (l *recordingLog) SaveDelivery(ctx context.Context, delivery *dtos.NotificationDelivery) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.deliveries = append(l.deliveries, *delivery)
+	return nil
+}
+
+func TestDispatchAsyncWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	log := &recordingLog{}
+	dispatcher := NewDispatcher(log, 3, 20*time.Millisecond, NewWebhookSender(true))
+	dispatcher.DispatchAsync(context.Background(), testNotification("Go jobs", "", server.URL))
+	dispatcher.Wait()
+
+	if len(log.deliveries) != 1 || log.deliveries[0].Attempts != 3 {
+		t.Fatalf("deliveries = %+v, want one delivery after all retries", log.deliveries)
+	}
+}
@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/netguard"
+	"github.com/lakshya1goel/job-assistance/internal/tracing"
+)
+
+const (
+	WebhookEvent           = "saved_search.new_matches"
+	WebhookTimestampHeader = "X-TalentX-Timestamp"
+	WebhookSignatureHeader = "X-TalentX-Signature"
+)
+
+type WebhookSender struct {
+	Client *http.Client
+}
+
+// NewWebhookSender posts to caller-supplied URLs, so unless allowPrivate is
+// set it refuses to connect to loopback, private and link-local addresses.
+func NewWebhookSender(allowPrivate bool) *WebhookSender {
+	return &WebhookSender{
+		Client: &http.Client{
+			Transport: tracing.Transport(netguard.NewTransport(allowPrivate)),
+			Timeout:   10 * time.Second,
+		},
+	}
+}
+
+type webhookJob struct {
+	Title        string  `json:"title"`
+	Company      string  `json:"company,omitempty"`
+	Location     string  `json:"location,omitempty"`
+	URL          string  `json:"url"`
+	PercentMatch float64 `json:"percent_match"`
+	MatchReason  string  `json:"match_reason,omitempty"`
+}
+
+// webhookPayload carries a human-readable "text" field so it can be posted
+// straight to Slack or Teams incoming webhooks as well as custom receivers.
+type webhookPayload struct {
+	Event      string       `json:"event"`
+	SearchID   string       `json:"search_id"`
+	SearchName string       `json:"search_name"`
+	RunID      string       `json:"run_id"`
+	Text       string       `json:"text"`
+	Total      int          `json:"total"`
+	Matches    []webhookJob `json:"matches"`
+	SentAt     time.Time    `json:"sent_at"`
+}
+
+func (w *WebhookSender) Channel() string {
+	return ChannelWebhook
+}
+
+func (w *WebhookSender) Send(ctx context.Context, target string, notification Notification) error {
+	payload := webhookPayload{
+		Event:      WebhookEvent,
+		SearchID:   notification.Search.ID,
+		SearchName: notification.Search.Name,
+		RunID:      notification.RunID,
+		Text:       summary(notification),
+		Total:      len(notification.Matches),
+		SentAt:     time.Now().UTC(),
+	}
+	for _, match := range topMatches(notification.Matches) {
+		payload.Text += fmt.Sprintf("\n• %s - %.0f%% match %s", describeJob(match.Job.Job), match.Job.PercentMatch, match.Job.Job.URL)
+		payload.Matches = append(payload.Matches, webhookJob{
+			Title:        match.Job.Job.Title,
+			Company:      match.Job.Job.Company,
+			Location:     match.Job.Job.Location,
+			URL:          match.Job.Job.URL,
+			PercentMatch: match.Job.PercentMatch,
+			MatchReason:  match.Job.MatchReason,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to marshal webhook payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to create webhook request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if secret := notification.Search.WebhookSecret; secret != "" {
+		timestamp := strconv.FormatInt(payload.SentAt.Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, Sign(secret, timestamp, body))
+	}
+
+	resp, err := w.Client.Do(req)
+	if errors.Is(err, netguard.ErrBlockedAddress) {
+		return Permanent(fmt.Errorf("webhook request failed: %w", err))
+	}
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout {
+		return err
+	}
+	return Permanent(err)
+}
+
+// Sign returns the signature receivers should compare against the
+// X-TalentX-Signature header: an HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
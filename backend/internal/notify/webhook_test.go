@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(WebhookTimestampHeader)
+		if got, want := r.Header.Get(WebhookSignatureHeader), Sign("secret", timestamp, body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	err := NewWebhookSender(true).Send(t.Context(), server.URL, testNotification("Go jobs", "", server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != WebhookEvent || payload.Total != 2 || len(payload.Matches) != 2 {
+		t.Errorf("payload = %+v", payload)
+	}
+	if payload.Matches[0].Title != "Go Engineer" {
+		t.Errorf("matches are not sorted by score: %+v", payload.Matches)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantStatus   string
+		wantAttempts int
+	}{
+		{"retries server errors", []int{503, 500, 200}, StatusDelivered, 3},
+		{"retries rate limiting", []int{429, 204}, StatusDelivered, 2},
+		{"gives up after max attempts", []int{502, 502, 502, 200}, StatusFailed, 3},
+		{"does not retry client errors", []int{400, 200}, StatusFailed, 1},
+		{"does not retry not found", []int{404, 200}, StatusFailed, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(calls.Add(1)) - 1
+				w.WriteHeader(test.statuses[min(call, len(test.statuses)-1)])
+			}))
+			defer server.Close()
+
+			dispatcher := NewDispatcher(nil, 3, time.Millisecond, NewWebhookSender(true))
+			deliveries := dispatcher.Dispatch(t.Context(), testNotification("Go jobs", "", server.URL))
+			if len(deliveries) != 1 {
+				t.Fatalf("got %d deliveries, want 1", len(deliveries))
+			}
+			delivery := deliveries[0]
+			if delivery.Status != test.wantStatus || delivery.Attempts != test.wantAttempts {
+				t.Errorf("status = %q after %d attempts, want %q after %d", delivery.Status, delivery.Attempts, test.wantStatus, test.wantAttempts)
+			}
+			if int(calls.Load()) != test.wantAttempts {
+				t.Errorf("receiver got %d requests, want %d", calls.Load(), test.wantAttempts)
+			}
+			if delivery.Target == server.URL {
+				t.Error("the webhook URL must not be stored in the delivery log")
+			}
+		})
+	}
+}
+
+func TestWebhookBlocksPrivateAddresses(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(nil, 3, time.Millisecond, NewWebhookSender(false))
+	deliveries := dispatcher.Dispatch(t.Context(), testNotification("Go jobs", "", server.URL))
+	if len(deliveries) != 1 || deliveries[0].Status != StatusFailed || deliveries[0].Attempts != 1 {
+		t.Fatalf("deliveries = %+v, want one failed attempt", deliveries)
+	}
+	if !strings.Contains(deliveries[0].Error, "not publicly routable") {
+		t.Errorf("error = %q", deliveries[0].Error)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("receiver got %d requests, want none", calls.Load())
+	}
+}
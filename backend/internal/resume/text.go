@@ -0,0 +1,97 @@
+// Package resume extracts plain text from resume PDFs locally, without
+// calling an LLM.
+package resume
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+var ErrNoText = errors.New("no extractable text in PDF")
+
+// ExtractText returns the PDF's text line by line, top to bottom. Scanned
+// resumes without a text layer return ErrNoText.
+func ExtractText(pdfBytes []byte) (text string, err error) {
+	// The PDF parser panics on some malformed files.
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("failed to parse PDF: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(pdfBytes), int64(len(pdfBytes)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open PDF: %w", err)
+	}
+
+	var b strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		for _, line := range pageLines(page.Content().Text) {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+
+	text = strings.TrimSpace(b.String())
+	if text == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
+
+// pageLines groups glyphs into lines by baseline and inserts spaces where
+// the gap between glyphs is wider than a fraction of the font size.
+func pageLines(glyphs []pdf.Text) []string {
+	type line struct {
+		y      float64
+		glyphs []pdf.Text
+	}
+	var lines []*line
+
+	for _, glyph := range glyphs {
+		var target *line
+		for _, l := range lines {
+			if math.Abs(l.y-glyph.Y) <= math.Max(1, glyph.FontSize*0.3) {
+				target = l
+				break
+			}
+		}
+		if target == nil {
+			target = &line{y: glyph.Y}
+			lines = append(lines, target)
+		}
+		target.glyphs = append(target.glyphs, glyph)
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].y > lines[j].y })
+
+	result := make([]string, 0, len(lines))
+	for _, l := range lines {
+		sort.SliceStable(l.glyphs, func(i, j int) bool { return l.glyphs[i].X < l.glyphs[j].X })
+
+		var b strings.Builder
+		end := math.Inf(-1)
+		for _, glyph := range l.glyphs {
+			if b.Len() > 0 && glyph.X-end > glyph.FontSize*0.15 && !strings.HasPrefix(glyph.S, " ") {
+				b.WriteByte(' ')
+			}
+			b.WriteString(glyph.S)
+			end = glyph.X + glyph.W
+		}
+
+		if text := strings.Join(strings.Fields(b.String()), " "); text != "" {
+			result = append(result, text)
+		}
+	}
+	return result
+}
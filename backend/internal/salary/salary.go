@@ -0,0 +1,86 @@
+package salary
+
+import (
+	"math"
+	"strings"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+const (
+	PeriodHour  = "HOUR"
+	PeriodDay   = "DAY"
+	PeriodWeek  = "WEEK"
+	PeriodMonth = "MONTH"
+	PeriodYear  = "YEAR"
+)
+
+var periodsPerYear = map[string]float64{
+	PeriodHour:  2080,
+	PeriodDay:   260,
+	PeriodWeek:  52,
+	PeriodMonth: 12,
+	PeriodYear:  1,
+}
+
+// Normalize upper-cases the currency and period and fills in the annual
+// amounts. Salaries with an unknown period are assumed to be yearly.
+func Normalize(salary *dtos.Salary) *dtos.Salary {
+	if salary == nil || (salary.Min == nil && salary.Max == nil) {
+		return nil
+	}
+
+	salary.Currency = strings.ToUpper(strings.TrimSpace(salary.Currency))
+	salary.Period = strings.ToUpper(strings.TrimSpace(salary.Period))
+	if _, ok := periodsPerYear[salary.Period]; !ok {
+		salary.Period = PeriodYear
+	}
+
+	if salary.Min != nil && salary.Max != nil && *salary.Min > *salary.Max {
+		salary.Min, salary.Max = salary.Max, salary.Min
+	}
+
+	multiplier := periodsPerYear[salary.Period]
+	salary.AnnualMin = annualize(salary.Min, multiplier)
+	salary.AnnualMax = annualize(salary.Max, multiplier)
+	return salary
+}
+
+// Convert converts an amount between currencies using rates expressed as
+// units per USD.
+func Convert(amount float64, from, to string, rates map[string]float64) (float64, bool) {
+	fromRate, ok := rates[strings.ToUpper(from)]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := rates[strings.ToUpper(to)]
+	if !ok {
+		return 0, false
+	}
+	return amount / fromRate * toRate, true
+}
+
+// AnnualUpperBound returns the highest annual amount the job advertises in the
+// requested currency.
+func AnnualUpperBound(salary *dtos.Salary, currency string, rates map[string]float64) (float64, bool) {
+	if salary == nil || salary.Currency == "" {
+		return 0, false
+	}
+
+	amount := salary.AnnualMax
+	if amount == nil {
+		amount = salary.AnnualMin
+	}
+	if amount == nil {
+		return 0, false
+	}
+	return Convert(*amount, salary.Currency, currency, rates)
+}
+
+func annualize(amount *float64, multiplier float64) *float64 {
+	if amount == nil {
+		return nil
+	}
+	annual := math.Round(*amount * multiplier)
+	return &annual
+}
@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const minEveryInterval = 15 * time.Minute
+
+// Schedule is a parsed cadence: either a standard five-field cron expression
+// (minute hour day-of-month month day-of-week) or "@every <duration>".
+type Schedule struct {
+	every   time.Duration
+	minute  []bool
+	hour    []bool
+	day     []bool
+	month   []bool
+	weekday []bool
+	anyDay  bool
+	anyWday bool
+}
+
+var scheduleAliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 9 * * *",
+	"@weekly":  "0 9 * * 1",
+	"@monthly": "0 9 1 * *",
+}
+
+func ParseSchedule(expression string) (*Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if alias, ok := scheduleAliases[expression]; ok {
+		expression = alias
+	}
+
+	if interval, ok := strings.CutPrefix(expression, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil {
+			return nil, fmt.Errorf("invalid @every duration: %w", err)
+		}
+		if every < minEveryInterval {
+			return nil, fmt.Errorf("@every interval must be at least %s", minEveryInterval)
+		}
+		return &Schedule{every: every}, nil
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule must have 5 fields (minute hour day month weekday), got %d", len(fields))
+	}
+
+	schedule := &Schedule{
+		anyDay:  fields[2] == "*",
+		anyWday: fields[4] == "*",
+	}
+	var err error
+	if schedule.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if schedule.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if schedule.day, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day field: %w", err)
+	}
+	if schedule.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if schedule.weekday, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid weekday field: %w", err)
+	}
+	if schedule.weekday[7] {
+		schedule.weekday[0] = true
+	}
+
+	// Sample a few days of activations so "* * * * *" style cadences that
+	// would burn through the LLM and search quotas are rejected up front.
+	previous := schedule.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if previous.IsZero() {
+		return nil, fmt.Errorf("schedule never runs")
+	}
+	for range 200 {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if next.Sub(previous) < minEveryInterval {
+			return nil, fmt.Errorf("schedule must not run more often than every %s", minEveryInterval)
+		}
+		previous = next
+	}
+	return schedule, nil
+}
+
+// Next returns the first activation strictly after the given time, or the
+// zero time if the expression can never match (e.g. February 30th).
+func (s *Schedule) Next(after time.Time) time.Time {
+	if s.every > 0 {
+		return after.Add(s.every)
+	}
+
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !s.month[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay follows cron semantics: when both day fields are restricted a
+// day matching either of them is enough.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayMatch := s.day[t.Day()]
+	weekdayMatch := s.weekday[int(t.Weekday())]
+	switch {
+	case s.anyDay && s.anyWday:
+		return true
+	case s.anyDay:
+		return weekdayMatch
+	case s.anyWday:
+		return dayMatch
+	default:
+		return dayMatch || weekdayMatch
+	}
+}
+
+func parseField(field string, low, high int) ([]bool, error) {
+	values := make([]bool, high+1)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = parsed
+		}
+
+		start, end := low, high
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = strconv.Atoi(from); err != nil {
+				return nil, fmt.Errorf("invalid value %q", from)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(to); err != nil {
+					return nil, fmt.Errorf("invalid value %q", to)
+				}
+			} else if hasStep {
+				end = high
+			}
+		}
+		if start < low || end > high || start > end {
+			return nil, fmt.Errorf("value %q out of range %d-%d", part, low, high)
+		}
+
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    bool
+	}{
+		{"@daily", false},
+		{"@hourly", false},
+		{"@weekly", false},
+		{"@monthly", false},
+		{"@every 1h", false},
+		{"@every 15m", false},
+		{"@every 5m", true},
+		{"@every soon", true},
+		{"0 9 * * 1-5", false},
+		{"*/30 * * * *", false},
+		{"0,30 8-18 * * *", false},
+		{"0 9 1,15 * *", false},
+		{"0 9 * * 7", false},
+		{"* * * * *", true},
+		{"*/5 * * * *", true},
+		{"0 9 * *", true},
+		{"0 9 * * * *", true},
+		{"60 9 * * *", true},
+		{"0 24 * * *", true},
+		{"0 9 0 * *", true},
+		{"0 9 * 13 *", true},
+		{"0 9 * * 8", true},
+		{"0 9 5-1 * *", true},
+		{"0 9 */0 * *", true},
+		{"0 9 a * *", true},
+		{"0 9 30 2 *", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			_, err := ParseSchedule(test.expression)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ParseSchedule(%q) error = %v, wantErr %v", test.expression, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		field string
+		low   int
+		high  int
+		want  []int
+	}{
+		{"*", 0, 5, []int{0, 1, 2, 3, 4, 5}},
+		{"3", 0, 5, []int{3}},
+		{"1-3", 0, 5, []int{1, 2, 3}},
+		{"*/2", 0, 5, []int{0, 2, 4}},
+		{"1/2", 0, 5, []int{1, 3, 5}},
+		{"0-4/3", 0, 5, []int{0, 3}},
+		{"1,4,5", 0, 5, []int{1, 4, 5}},
+		{"1-2,5", 1, 5, []int{1, 2, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			values, err := parseField(test.field, test.low, test.high)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []int
+			for value, set := range values {
+				if set {
+					got = append(got, value)
+				}
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("parseField(%q) = %v, want %v", test.field, got, test.want)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// 2024-01-01 is a Monday.
+	at := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02 15:04", value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		after      string
+		want       string
+	}{
+		{"daily alias later today", "@daily", "2024-01-01 08:00", "2024-01-01 09:00"},
+		{"daily alias tomorrow", "@daily", "2024-01-01 09:00", "2024-01-02 09:00"},
+		{"strictly after", "30 10 * * *", "2024-01-01 10:30", "2024-01-02 10:30"},
+		{"seconds are truncated", "30 10 * * *", "2024-01-01 10:29", "2024-01-01 10:30"},
+		{"weekdays skip the weekend", "0 9 * * 1-5", "2024-01-05 10:00", "2024-01-08 09:00"},
+		{"sunday as 7", "0 9 * * 7", "2024-01-01 00:00", "2024-01-07 09:00"},
+		{"month rollover", "0 9 1 * *", "2024-01-15 00:00", "2024-02-01 09:00"},
+		{"year rollover", "0 0 1 1 *", "2024-06-01 00:00", "2025-01-01 00:00"},
+		{"leap day", "0 12 29 2 *", "2024-03-01 00:00", "2028-02-29 12:00"},
+		{"day-of-month or weekday: weekday first", "0 9 15 * 5", "2024-01-01 00:00", "2024-01-05 09:00"},
+		{"day-of-month or weekday: day first", "0 9 2 * 5", "2024-01-01 00:00", "2024-01-02 09:00"},
+		{"restricted day with any weekday", "0 9 15 * *", "2024-01-01 00:00", "2024-01-15 09:00"},
+		{"restricted weekday with any day", "0 9 * * 3", "2024-01-01 00:00", "2024-01-03 09:00"},
+		{"stepped day is not any day", "0 9 */10 * 5", "2024-01-02 00:00", "2024-01-05 09:00"},
+		{"every interval", "@every 2h", "2024-01-01 08:17", "2024-01-01 10:17"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(test.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := schedule.Next(at(test.after)); !got.Equal(at(test.want)) {
+				t.Errorf("Next(%s) = %s, want %s", test.after, got.Format("2006-01-02 15:04"), test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+	"github.com/lakshya1goel/job-assistance/internal/logger"
+)
+
+type Runner interface {
+	ListScheduledSearches(ctx context.Context) ([]dtos.SavedSearch, error)
+	RunScheduledSearch(ctx context.Context, id string) (*dtos.SavedSearchRunResult, error)
+}
+
+type Scheduler struct {
+	runner      Runner
+	interval    time.Duration
+	concurrency int
+
+	mu      sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+}
+
+func NewScheduler(runner Runner, interval time.Duration, concurrency int) *Scheduler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Scheduler{
+		runner:      runner,
+		interval:    interval,
+		concurrency: concurrency,
+		running:     make(map[string]bool),
+	}
+}
+
+// Start checks for due saved searches every interval until ctx is cancelled.
+// The returned function waits for in-flight runs to finish.
+func (s *Scheduler) Start(ctx context.Context) func() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.runDue(ctx, now)
+			}
+		}
+	}()
+
+	return func() {
+		<-done
+		s.wg.Wait()
+	}
+}
+
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	log := logger.FromContext(ctx)
+
+	searches, err := s.runner.ListScheduledSearches(ctx)
+	if err != nil {
+		log.Error("failed to list saved searches", "error", err)
+		return
+	}
+
+	for _, search := range searches {
+		if !search.Enabled || search.NextRunAt == nil || search.NextRunAt.After(now) {
+			continue
+		}
+		if !s.acquire(search.ID) {
+			continue
+		}
+
+		s.wg.Add(1)
+		go func(id string) {
+			defer s.wg.Done()
+			defer s.release(id)
+
+			runCtx := logger.WithRequestID(ctx, "scheduled-"+id)
+			// A panic in one search must not take the whole server down.
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					logger.FromContext(runCtx).Error("scheduled saved search panicked", "search_id", id, "panic", recovered, "stack", string(debug.Stack()))
+				}
+			}()
+
+			if _, err := s.runner.RunScheduledSearch(runCtx, id); err != nil {
+				logger.FromContext(runCtx).Error("scheduled saved search failed", "search_id", id, "error", err)
+			}
+		}(search.ID)
+	}
+}
+
+func (s *Scheduler) acquire(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running[id] || len(s.running) >= s.concurrency {
+		return false
+	}
+	s.running[id] = true
+	return true
+}
+
+func (s *Scheduler) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, id)
+}
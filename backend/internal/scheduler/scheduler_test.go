@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+type panickingRunner struct {
+	runs atomic.Int32
+}
+
+func (r *panickingRunner) ListScheduledSearches(ctx context.Context) ([]dtos.SavedSearch, error) {
+	due := time.Now().Add(-time.Minute)
+	return []dtos.SavedSearch{
+		{ID: "panics", Enabled: true, NextRunAt: &due},
+		{ID: "works", Enabled: true, NextRunAt: &due},
+		{ID: "disabled", Enabled: false, NextRunAt: &due},
+	}, nil
+}
+
+func (r *panickingRunner) RunScheduledSearch(ctx context.Context, id string) (*dtos.SavedSearchRunResult, error) {
+	r.runs.Add(1)
+	if id == "panics" {
+		panic("boom")
+	}
+	return &dtos.SavedSearchRunResult{SearchID: id}, nil
+}
+
+func TestRunDueRecoversFromPanics(t *testing.T) {
+	runner := &panickingRunner{}
+	scheduler := NewScheduler(runner, time.Minute, 2)
+
+	scheduler.runDue(context.Background(), time.Now())
+	scheduler.wg.Wait()
+
+	if got := runner.runs.Load(); got != 2 {
+		t.Errorf("ran %d searches, want 2", got)
+	}
+	if len(scheduler.running) != 0 {
+		t.Errorf("searches still marked running: %v", scheduler.running)
+	}
+}
@@ -0,0 +1,275 @@
+package skills
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed taxonomy.json
+var bundledTaxonomy []byte
+
+type Skill struct {
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	Category  string   `json:"category"`
+	Parent    string   `json:"parent,omitempty"`
+	Ambiguous bool     `json:"ambiguous,omitempty"`
+}
+
+type Taxonomy struct {
+	skills map[string]Skill
+	index  map[string]string
+	terms  []term
+}
+
+type term struct {
+	text          string
+	canonical     string
+	caseSensitive bool
+}
+
+var (
+	defaultTaxonomy *Taxonomy
+	defaultOnce     sync.Once
+)
+
+func Default() *Taxonomy {
+	defaultOnce.Do(func() {
+		taxonomy, err := Parse(bundledTaxonomy)
+		if err != nil {
+			panic(fmt.Sprintf("invalid bundled skill taxonomy: %v", err))
+		}
+		defaultTaxonomy = taxonomy
+	})
+	return defaultTaxonomy
+}
+
+func SetDefault(taxonomy *Taxonomy) {
+	defaultOnce.Do(func() {})
+	defaultTaxonomy = taxonomy
+}
+
+func LoadFile(path string) (*Taxonomy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skill taxonomy: %w", err)
+	}
+	return Parse(data)
+}
+
+func Parse(data []byte) (*Taxonomy, error) {
+	var file struct {
+		Skills []Skill `json:"skills"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse skill taxonomy: %w", err)
+	}
+
+	taxonomy := &Taxonomy{
+		skills: make(map[string]Skill, len(file.Skills)),
+		index:  make(map[string]string),
+	}
+
+	for _, skill := range file.Skills {
+		if strings.TrimSpace(skill.Name) == "" {
+			return nil, fmt.Errorf("skill taxonomy entry without a name")
+		}
+		if _, exists := taxonomy.skills[key(skill.Name)]; exists {
+			return nil, fmt.Errorf("duplicate skill %q in taxonomy", skill.Name)
+		}
+		taxonomy.skills[key(skill.Name)] = skill
+
+		for _, text := range append([]string{skill.Name}, skill.Aliases...) {
+			if existing, ok := taxonomy.index[key(text)]; ok && existing != skill.Name {
+				return nil, fmt.Errorf("alias %q maps to both %q and %q", text, existing, skill.Name)
+			}
+			taxonomy.index[key(text)] = skill.Name
+			taxonomy.terms = append(taxonomy.terms, term{
+				text:          text,
+				canonical:     skill.Name,
+				caseSensitive: len(text) <= 4 && (skill.Ambiguous || len(text) <= 2),
+			})
+		}
+	}
+
+	for _, skill := range taxonomy.skills {
+		if skill.Parent != "" {
+			if _, ok := taxonomy.skills[key(skill.Parent)]; !ok {
+				return nil, fmt.Errorf("skill %q has unknown parent %q", skill.Name, skill.Parent)
+			}
+		}
+	}
+
+	// Longer terms first so "React Native" is preferred over "React".
+	sort.SliceStable(taxonomy.terms, func(i, j int) bool {
+		return len(taxonomy.terms[i].text) > len(taxonomy.terms[j].text)
+	})
+
+	return taxonomy, nil
+}
+
+func (t *Taxonomy) Canonical(skill string) string {
+	skill = strings.TrimSpace(skill)
+	if canonical, ok := t.index[key(skill)]; ok {
+		return canonical
+	}
+	return skill
+}
+
+func (t *Taxonomy) Lookup(skill string) (Skill, bool) {
+	canonical, ok := t.index[key(skill)]
+	if !ok {
+		return Skill{}, false
+	}
+	return t.skills[key(canonical)], true
+}
+
+func (t *Taxonomy) Normalize(skills []string) []string {
+	if skills == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(skills))
+	normalized := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		canonical := t.Canonical(skill)
+		if canonical == "" || seen[key(canonical)] {
+			continue
+		}
+		seen[key(canonical)] = true
+		normalized = append(normalized, canonical)
+	}
+	return normalized
+}
+
+func (t *Taxonomy) Extract(text string) []string {
+	lower := lowerSameLength(text)
+	consumed := make([]bool, len(text))
+	seen := make(map[string]bool)
+	var found []string
+
+	for _, candidate := range t.terms {
+		haystack, needle := lower, lowerSameLength(candidate.text)
+		if candidate.caseSensitive {
+			haystack, needle = text, candidate.text
+		}
+
+		for _, start := range matchPositions(haystack, needle) {
+			end := start + len(needle)
+			if overlaps(consumed, start, end) {
+				continue
+			}
+			for i := start; i < end; i++ {
+				consumed[i] = true
+			}
+			if !seen[candidate.canonical] {
+				seen[candidate.canonical] = true
+				found = append(found, candidate.canonical)
+			}
+		}
+	}
+
+	sort.Strings(found)
+	return found
+}
+
+func (t *Taxonomy) Contains(text, skill string) bool {
+	canonical := t.Canonical(skill)
+	for _, found := range t.Extract(text) {
+		if found == canonical {
+			return true
+		}
+	}
+	if _, known := t.index[key(skill)]; !known {
+		return len(matchPositions(strings.ToLower(text), key(skill))) > 0
+	}
+	return false
+}
+
+func (t *Taxonomy) Related(a, b string) bool {
+	a, b = t.Canonical(a), t.Canonical(b)
+	if key(a) == key(b) {
+		return true
+	}
+	if skill, ok := t.skills[key(a)]; ok && key(skill.Parent) == key(b) {
+		return true
+	}
+	if skill, ok := t.skills[key(b)]; ok && key(skill.Parent) == key(a) {
+		return true
+	}
+	return false
+}
+
+func key(skill string) string {
+	return strings.ToLower(strings.TrimSpace(skill))
+}
+
+// lowerSameLength lower-cases text without changing its byte length, so
+// offsets found in the result are valid in text. strings.ToLower can grow or
+// shrink the string: invalid bytes become U+FFFD and some runes change size
+// when lowered. Those are left as they are.
+func lowerSameLength(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		lowered := unicode.ToLower(r)
+		if r == utf8.RuneError || utf8.RuneLen(lowered) != size {
+			b.WriteString(text[i : i+size])
+		} else {
+			b.WriteRune(lowered)
+		}
+		i += size
+	}
+	return b.String()
+}
+
+func matchPositions(text, needle string) []int {
+	if needle == "" {
+		return nil
+	}
+
+	var positions []int
+	for offset := 0; offset < len(text); {
+		index := strings.Index(text[offset:], needle)
+		if index == -1 {
+			break
+		}
+		start := offset + index
+		if isWordBoundary(text, start, start+len(needle)) {
+			positions = append(positions, start)
+		}
+		offset = start + 1
+	}
+	return positions
+}
+
+func overlaps(consumed []bool, start, end int) bool {
+	for i := start; i < end && i < len(consumed); i++ {
+		if consumed[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// isWordBoundary reports whether text[start:end] stands on its own. '+' and
+// '#' count as part of a word so "C" does not match inside "C++" or "C#".
+func isWordBoundary(text string, start, end int) bool {
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(before) {
+		return false
+	}
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return !isWordRune(after)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#'
+}
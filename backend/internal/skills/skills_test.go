@@ -0,0 +1,43 @@
+package skills
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractNonASCIIText(t *testing.T) {
+	taxonomy := Default()
+
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"invalid UTF-8", "Caf\xe9 \xe9\xe9\xe9\xe9 team needs Kubernetes", []string{"Kubernetes"}},
+		{"runes that grow when lowered", "ȺȺȺȺȺ team needs Kubernetes", []string{"Kubernetes"}},
+		{"runes that shrink when lowered", "ẞẞẞẞẞẞ team needs golang and k8s", []string{"Go", "Kubernetes"}},
+		{"accented text", "Développeur Go à Paris, PostgreSQL", []string{"Go", "PostgreSQL"}},
+		{"multi-byte punctuation", "Go—Kubernetes", []string{"Go", "Kubernetes"}},
+		{"accented letter before a skill", "caféjava and Kubernetes", []string{"Kubernetes"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := taxonomy.Extract(test.text)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Extract(%q) = %v, want %v", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestLowerSameLength(t *testing.T) {
+	for _, text := range []string{"Caf\xe9", "ȺȺ", "ẞ", "İstanbul", "GoLang"} {
+		if got := lowerSameLength(text); len(got) != len(text) {
+			t.Errorf("lowerSameLength(%q) has length %d, want %d", text, len(got), len(text))
+		}
+	}
+	if got := lowerSameLength("GoLang"); got != "golang" {
+		t.Errorf("lowerSameLength(\"GoLang\") = %q, want \"golang\"", got)
+	}
+}
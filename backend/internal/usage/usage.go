@@ -0,0 +1,107 @@
+package usage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lakshya1goel/job-assistance/config"
+	"github.com/lakshya1goel/job-assistance/internal/dtos"
+)
+
+type contextKey struct{}
+
+type Tracker struct {
+	mu            sync.Mutex
+	stages        map[string]*dtos.StageUsage
+	models        map[string]*dtos.StageUsage
+	externalCalls map[string]int
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		stages:        map[string]*dtos.StageUsage{},
+		models:        map[string]*dtos.StageUsage{},
+		externalCalls: map[string]int{},
+	}
+}
+
+func WithTracker(ctx context.Context, tracker *Tracker) context.Context {
+	return context.WithValue(ctx, contextKey{}, tracker)
+}
+
+func FromContext(ctx context.Context) *Tracker {
+	tracker, _ := ctx.Value(contextKey{}).(*Tracker)
+	return tracker
+}
+
+func RecordLLM(ctx context.Context, stage, model string, promptTokens, responseTokens, totalTokens int) {
+	tracker := FromContext(ctx)
+	if tracker == nil {
+		return
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	for _, entry := range []*dtos.StageUsage{tracker.entry(tracker.stages, stage), tracker.entry(tracker.models, model)} {
+		entry.Calls++
+		entry.PromptTokens += promptTokens
+		entry.ResponseTokens += responseTokens
+		entry.TotalTokens += totalTokens
+	}
+}
+
+func RecordExternalCall(ctx context.Context, source string) {
+	tracker := FromContext(ctx)
+	if tracker == nil {
+		return
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.externalCalls[source]++
+}
+
+func (t *Tracker) entry(entries map[string]*dtos.StageUsage, key string) *dtos.StageUsage {
+	entry, ok := entries[key]
+	if !ok {
+		entry = &dtos.StageUsage{}
+		entries[key] = entry
+	}
+	return entry
+}
+
+func (t *Tracker) Summary(prices map[string]config.ModelPrice) *dtos.UsageSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summary := &dtos.UsageSummary{
+		Stages:        make(map[string]dtos.StageUsage, len(t.stages)),
+		ExternalCalls: make(map[string]int, len(t.externalCalls)),
+	}
+
+	for stage, entry := range t.stages {
+		summary.Stages[stage] = *entry
+		summary.PromptTokens += entry.PromptTokens
+		summary.ResponseTokens += entry.ResponseTokens
+		summary.TotalTokens += entry.TotalTokens
+		summary.LLMCalls += entry.Calls
+	}
+
+	for model, entry := range t.models {
+		price, ok := prices[model]
+		if !ok {
+			summary.UnpricedModels = append(summary.UnpricedModels, model)
+			continue
+		}
+		summary.EstimatedCostUSD += float64(entry.PromptTokens)/1_000_000*price.InputPerMillion +
+			float64(entry.ResponseTokens)/1_000_000*price.OutputPerMillion
+	}
+
+	for source, calls := range t.externalCalls {
+		summary.ExternalCalls[source] = calls
+		summary.TotalExternalCalls += calls
+	}
+
+	return summary
+}